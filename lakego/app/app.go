@@ -9,23 +9,33 @@ import (
     "log"
     "sync"
     "errors"
+    "strings"
+    "time"
     "context"
     "reflect"
+    "syscall"
 
-    "github.com/deatil/go-datebin/datebin"
     "github.com/deatil/lakego-jwt/jwt"
     "github.com/deatil/lakego-doak/lakego/di"
     "github.com/deatil/lakego-doak/lakego/env"
     "github.com/deatil/lakego-doak/lakego/path"
     "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/events"
     "github.com/deatil/lakego-doak/lakego/config"
     "github.com/deatil/lakego-doak/lakego/command"
     "github.com/deatil/lakego-doak/lakego/schedule"
     "github.com/deatil/lakego-doak/lakego/facade"
+    "github.com/deatil/lakego-doak/lakego/middleware/logger"
+    "github.com/deatil/lakego-doak/lakego/middleware/cors"
+    "github.com/deatil/lakego-doak/lakego/middleware/accesslog"
     "github.com/deatil/lakego-doak/lakego/middleware/recovery"
+    "github.com/deatil/lakego-doak/lakego/middleware/maintenance"
     iprovider "github.com/deatil/lakego-doak/lakego/provider/interfaces"
 )
 
+// 默认关闭等待时间
+const DefaultShutdownTimeout = 10 * time.Second
+
 // 计划任务接口
 type ServiceProviderSchedule interface {
     Schedule(*schedule.Schedule)
@@ -92,7 +102,10 @@ func New() *App {
     }
 
     // 计划任务
-    scheduler := schedule.New().SetShowLogInfo(dev)
+    scheduler := schedule.New().
+        SetShowLogInfo(dev).
+        WithEnvironment(mode).
+        WithCacheStore(cfg.GetString("schedule.cache-store"))
 
     return &App{
         dev:              dev,
@@ -112,15 +125,19 @@ func (this *App) WithConfig(conf *config.Config) *App {
 }
 
 // 运行
-func (this *App) Run() {
+func (this *App) Run() error {
     // 导入环境变量
-    this.loadEnv()
+    if err := this.loadEnv(); err != nil {
+        return err
+    }
 
     // 初始化容器
     this.initDI()
 
     // 运行
     this.runApp()
+
+    return nil
 }
 
 // 注册服务提供者
@@ -273,6 +290,11 @@ func (this *App) CallBootedCallbacks() {
 // 设置根脚本
 func (this *App) WithRootCmd(cmd *command.Command) {
     this.rootCmd = cmd
+
+    // 计划任务执行脚本使用
+    if this.schedule != nil {
+        this.schedule.WithRootCmd(cmd)
+    }
 }
 
 // 获取根脚本
@@ -283,6 +305,10 @@ func (this *App) GetRootCmd() *command.Command {
 // 设置计划任务
 func (this *App) WithSchedule(cron *schedule.Schedule) {
     this.schedule = cron
+
+    if this.rootCmd != nil {
+        this.schedule.WithRootCmd(this.rootCmd)
+    }
 }
 
 // 获取计划任务
@@ -382,22 +408,33 @@ func (this *App) runApp() {
         r = router.New()
     }
 
-    // 日志记录方式
-    logType := serverConf.GetString("log-type")
-    if logType == "file" {
-        logFileName := datebin.Now().Format("Ymd")
-        logFile := path.RuntimePath(fmt.Sprintf("/log/route_%s.log", logFileName))
-
-        // 设置默认日志记录
-        file, err := os.Create(logFile)
-        if err == nil {
-            router.WithDefaultWriter(file)
+    // 信任的代理，只有来自这些地址的请求才使用 X-Forwarded-For 等头信息获取 IP
+    // 没有配置时保持 gin 的默认设置信任全部代理，兼容已有的部署
+    // 升级后建议配置为实际的代理地址，配置为空列表时不信任任何代理，使用连接的 IP
+    if serverConf.IsSet("trusted-proxies") {
+        if err := r.SetTrustedProxies(serverConf.GetStringSlice("trusted-proxies")); err != nil {
+            log.Println("Trusted Proxies:", err)
         }
     }
 
     // 全局中间件
+    r.Use(logger.Handler())
+
+    // 访问日志
+    if serverConf.GetBool("access-log.enable") {
+        r.Use(accesslog.Handler())
+    }
+
+    // 跨域请求，预检请求没有对应路由，需要全局启用
+    if serverConf.GetBool("cors.enable") {
+        r.Use(cors.Handler())
+    }
+
     r.Use(recovery.Handler())
 
+    // 维护模式
+    r.Use(maintenance.Handler())
+
     // 缓存路由信息
     router.DefaultRoute().With(r)
 
@@ -458,30 +495,38 @@ func (this *App) loadServiceProvider() {
 func (this *App) serverRun() {
     conf := this.config
 
-    // 报错数据
-    var err error
+    srv := &http.Server{
+        Handler:        this.route.Handler(),
+        MaxHeaderBytes: 1 << 20,
+    }
+
+    // 关闭服务的等待时间，为空时使用配置 shutdown-timeout
+    var timeout time.Duration
+
+    // 启动服务
+    var serve func() error
 
     // 运行方式
     runType := conf.GetString("default")
+
     switch runType {
         case "http":
-            // 运行方式
-            servertype := conf.GetString("types.http.server-type")
-
             // 运行端口
-            addr := conf.GetString("types.http.addr")
+            srv.Addr = conf.GetString("types.http.addr")
 
-            if servertype == "grace" {
-                // 优雅地关机
-                this.graceRun(addr)
-            } else {
-                // gin 自带运行
-                err = this.route.Run(addr)
+            // 优雅地关机时使用的超时时间
+            if conf.GetString("types.http.server-type") == "grace" {
+                srv.ReadTimeout = conf.GetDuration("types.http.grace-read-timeout")
+                srv.WriteTimeout = conf.GetDuration("types.http.grace-write-timeout")
+
+                timeout = conf.GetDuration("types.http.grace-timeout")
             }
 
+            serve = srv.ListenAndServe
+
         case "tls":
             // 运行端口
-            addr := conf.GetString("types.tls.addr")
+            srv.Addr = conf.GetString("types.tls.addr")
 
             certFile := conf.GetString("types.tls.cert-file")
             keyFile := conf.GetString("types.tls.key-file")
@@ -490,7 +535,9 @@ func (this *App) serverRun() {
             certFile = this.formatPath(certFile)
             keyFile = this.formatPath(keyFile)
 
-            err = this.route.RunTLS(addr, certFile, keyFile)
+            serve = func() error {
+                return srv.ListenAndServeTLS(certFile, keyFile)
+            }
 
         case "unix":
             // 文件
@@ -499,70 +546,118 @@ func (this *App) serverRun() {
             // 格式化
             file = this.formatPath(file)
 
-            err = this.route.RunUnix(file)
+            serve = func() error {
+                listener, err := net.Listen("unix", file)
+                if err != nil {
+                    return err
+                }
+                defer os.Remove(file)
+
+                return srv.Serve(listener)
+            }
 
         case "fd":
             // fd
             fd := conf.GetInt("types.fd.fd")
 
-            err = this.route.RunFd(fd)
+            serve = func() error {
+                f := os.NewFile(uintptr(fd), fmt.Sprintf("fd@%d", fd))
+
+                listener, err := net.FileListener(f)
+                if err != nil {
+                    return err
+                }
+
+                return srv.Serve(listener)
+            }
 
         case "net-listener":
-            if this.netListener != nil {
-                err = this.route.RunListener(this.netListener)
-            } else {
+            serve = func() error {
+                if this.netListener != nil {
+                    return srv.Serve(this.netListener)
+                }
+
                 // 监听
                 typ := conf.GetString("types.net-listener.type")
                 addr := conf.GetString("types.net-listener.addr")
 
-                netListener, _ := net.Listen(typ, addr)
+                listener, err := net.Listen(typ, addr)
+                if err != nil {
+                    return err
+                }
 
-                err = this.route.RunListener(netListener)
+                return srv.Serve(listener)
             }
 
         default:
-            err = errors.New("服务启动错误")
+            log.Fatalf("server err: %s\n", errors.New("服务启动错误"))
     }
 
-    if err != nil {
-        log.Fatalf("server err: %s\n", err)
-    }
+    this.runServer(srv, serve, timeout)
 }
 
-// 优雅地关机
-func (this *App) graceRun(address string) {
-    conf := this.config
-
-    srv := &http.Server{
-        Addr:           address,
-        Handler:        this.route,
-        ReadTimeout:    conf.GetDuration("types.http.grace-read-timeout"),
-        WriteTimeout:   conf.GetDuration("types.http.grace-write-timeout"),
-        MaxHeaderBytes: 1 << 20,
-    }
+// 运行服务，收到退出信号时先关闭服务，等待正在处理的请求完成后再关闭应用
+func (this *App) runServer(srv *http.Server, serve func() error, timeout time.Duration) {
+    ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+    defer stop()
 
+    errs := make(chan error, 1)
     go func() {
-        // 服务连接
-        if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-            log.Fatalf("listen: %s\n", err)
-        }
+        errs <- serve()
     }()
 
-    // 等待中断信号以优雅地关闭服务器（设置 5 秒的超时时间）
-    quit := make(chan os.Signal)
-    signal.Notify(quit, os.Interrupt)
-    <-quit
+    select {
+        case err := <-errs:
+            if err != nil && err != http.ErrServerClosed {
+                log.Fatalf("server err: %s\n", err)
+            }
+
+            return
+        case <-ctx.Done():
+    }
+
+    // 恢复默认处理，再次收到信号时直接退出
+    stop()
+
     log.Println("Shutdown Server ...")
 
-    ctx, cancel := context.WithTimeout(context.Background(), conf.GetDuration("types.http.grace-timeout"))
+    if timeout <= 0 {
+        timeout = this.shutdownTimeout()
+    }
+
+    shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
     defer cancel()
-    if err := srv.Shutdown(ctx); err != nil {
-        log.Fatal("Server Shutdown:", err)
+
+    if err := srv.Shutdown(shutdownCtx); err != nil {
+        log.Println("Server Shutdown:", err)
     }
 
+    this.Shutdown()
+
     log.Println("Server exiting")
 }
 
+// 关闭应用，等待异步事件监听器执行完成
+// 等待时间使用配置 shutdown-timeout
+func (this *App) Shutdown() {
+    ctx, cancel := context.WithTimeout(context.Background(), this.shutdownTimeout())
+    defer cancel()
+
+    if err := events.Shutdown(ctx); err != nil {
+        log.Println("Events Shutdown:", err)
+    }
+}
+
+// 关闭等待时间
+func (this *App) shutdownTimeout() time.Duration {
+    timeout := this.config.GetDuration("shutdown-timeout")
+    if timeout <= 0 {
+        timeout = DefaultShutdownTimeout
+    }
+
+    return timeout
+}
+
 /**
  * 初始化容器
  */
@@ -576,12 +671,29 @@ func (this *App) initDI() {
 }
 
 // 导入 env 环境变量
-func (this *App) loadEnv() {
+// 导入顺序 .env < .env.{mode} < .env.local
+func (this *App) loadEnv() error {
+    // 运行模式
+    mode := env.Get(env.ModeKey)
+    if mode == "" {
+        mode = this.config.GetString("mode")
+    }
+
     // 环境变量
-    err := env.Load()
+    err := env.NewLoader().
+        WithMode(mode).
+        WithRequired(this.config.GetStringSlice("env-required")...).
+        Load()
     if err != nil {
-        log.Println("环境变量导入失败，原因为：" + err.Error())
+        var missingErr *env.MissingError
+        if errors.As(err, &missingErr) {
+            return fmt.Errorf("环境变量缺失：%s", strings.Join(missingErr.Keys, ", "))
+        }
+
+        return fmt.Errorf("环境变量导入失败，原因为：%w", err)
     }
+
+    return nil
 }
 
 // 格式化文件路径
@@ -57,4 +57,7 @@ type App interface {
 
     // 是否为开发者模式
     IsDev() bool
+
+    // 关闭应用
+    Shutdown()
 }
@@ -104,6 +104,24 @@ func (this *Cache) Put(key string, value any, ttl any) error {
     return this.driver.Put(key, value, expiration)
 }
 
+// 不存在时设置，返回是否设置成功
+// 驱动没有实现锁接口时使用非原子的判断及存储
+func (this *Cache) Add(key string, value any, ttl any) (bool, error) {
+    key = this.wrapperKey(key)
+
+    expiration := this.formatTime(ttl)
+
+    if driver, ok := this.driver.(interfaces.LockDriver); ok {
+        return driver.Add(key, value, expiration)
+    }
+
+    if this.driver.Exists(key) {
+        return false, nil
+    }
+
+    return true, this.driver.Put(key, value, expiration)
+}
+
 // 永久设置
 func (this *Cache) Forever(key string, value any) error {
     key = this.wrapperKey(key)
@@ -142,6 +160,50 @@ func (this *Cache) Decrement(key string, value ...int64) error {
     return this.driver.Decrement(key, value...)
 }
 
+// 计数加一并返回计数，计数不存在时创建并设置过期时间
+func (this *Cache) Hit(key string, ttl any) (int64, error) {
+    key = this.wrapperKey(key)
+
+    return this.hit(key, this.formatTime(ttl))
+}
+
+// 滑动窗口计数，上个窗口计数乘以权重加上当前窗口计数后还可以加一时当前窗口计数加一
+// 返回上个窗口计数，当前窗口计数及是否计数成功
+func (this *Cache) HitSliding(
+    previousKey string,
+    currentKey string,
+    weight float64,
+    max int64,
+    ttl any,
+) (int64, int64, bool, error) {
+    previousKey = this.wrapperKey(previousKey)
+    currentKey = this.wrapperKey(currentKey)
+
+    expiration := this.formatTime(ttl)
+
+    if driver, ok := this.driver.(interfaces.CounterDriver); ok {
+        return driver.HitSliding(previousKey, currentKey, weight, max, expiration)
+    }
+
+    previous := this.count(previousKey)
+
+    // 先计数再判断，超出时回退，使用计数后的值判断保证不会超出限制
+    current, err := this.hit(currentKey, expiration)
+    if err != nil {
+        return 0, 0, false, err
+    }
+
+    if float64(previous) * weight + float64(current) > float64(max) {
+        if err := this.driver.Decrement(currentKey); err != nil {
+            return 0, 0, false, err
+        }
+
+        return previous, current - 1, false, nil
+    }
+
+    return previous, current, true, nil
+}
+
 // 删除
 func (this *Cache) Forget(key string) (bool, error) {
     key = this.wrapperKey(key)
@@ -163,6 +225,37 @@ func (this *Cache) wrapperKey(key string) string {
     return fmt.Sprintf("%s:%s", this.prefix, key)
 }
 
+// 计数加一并返回计数
+func (this *Cache) hit(key string, ttl time.Duration) (int64, error) {
+    if driver, ok := this.driver.(interfaces.CounterDriver); ok {
+        return driver.Hit(key, ttl)
+    }
+
+    if err := this.driver.Increment(key); err != nil {
+        return 0, err
+    }
+
+    // 新建的计数设置过期时间，不会重置已有的计数
+    count := this.count(key)
+    if count == 1 {
+        if err := this.driver.Put(key, count, ttl); err != nil {
+            return 0, err
+        }
+    }
+
+    return count, nil
+}
+
+// 计数
+func (this *Cache) count(key string) int64 {
+    value, err := this.driver.Get(key)
+    if err != nil || value == nil {
+        return 0
+    }
+
+    return goch.ToInt64(value)
+}
+
 // 时间格式化
 func (this *Cache) formatTime(t any) time.Duration {
     return time.Second * goch.ToDuration(t)
@@ -0,0 +1,302 @@
+package memory
+
+import (
+    "fmt"
+    "sync"
+    "time"
+    "errors"
+
+    "github.com/deatil/go-goch/goch"
+)
+
+// 默认清理过期数据的间隔
+const DefaultSweepInterval = time.Minute
+
+// 数据
+type item struct {
+    // 值
+    value any
+
+    // 过期时间，为空时不过期
+    expiration time.Time
+}
+
+// 是否过期
+func (this item) expired(now time.Time) bool {
+    return !this.expiration.IsZero() && now.After(this.expiration)
+}
+
+/**
+ * 内存缓存，只在当前进程内有效
+ * 写入时按间隔清理全部过期数据，避免只写不读的数据一直保留
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Memory struct {
+    // 锁
+    mu sync.Mutex
+
+    // 数据
+    items map[string]item
+
+    // 清理过期数据的间隔
+    sweepInterval time.Duration
+
+    // 下次清理时间
+    nextSweep time.Time
+}
+
+// 构造函数
+func New() *Memory {
+    return &Memory{
+        items:         make(map[string]item),
+        sweepInterval: DefaultSweepInterval,
+    }
+}
+
+// 设置清理过期数据的间隔
+func (this *Memory) WithSweepInterval(interval time.Duration) *Memory {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if interval > 0 {
+        this.sweepInterval = interval
+        this.nextSweep = time.Now().Add(interval)
+    }
+
+    return this
+}
+
+// 判断是否存在
+func (this *Memory) Exists(key string) bool {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    _, ok := this.get(key)
+    return ok
+}
+
+// 获取
+func (this *Memory) Get(key string) (any, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    it, ok := this.get(key)
+    if !ok {
+        return nil, errors.New("memory nil")
+    }
+
+    return it.value, nil
+}
+
+// 设置
+func (this *Memory) Put(key string, value any, ttl time.Duration) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.sweep()
+
+    this.items[key] = newItem(value, ttl)
+
+    return nil
+}
+
+// 存在永久
+func (this *Memory) Forever(key string, value any) error {
+    return this.Put(key, value, 0)
+}
+
+// 自增
+func (this *Memory) Increment(key string, value ...int64) error {
+    var step int64 = 1
+    if len(value) > 0 {
+        step = value[0]
+    }
+
+    return this.incr(key, step)
+}
+
+// 自减
+func (this *Memory) Decrement(key string, value ...int64) error {
+    var step int64 = 1
+    if len(value) > 0 {
+        step = value[0]
+    }
+
+    return this.incr(key, -step)
+}
+
+// 删除
+func (this *Memory) Forget(key string) (bool, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if _, ok := this.get(key); !ok {
+        return false, nil
+    }
+
+    delete(this.items, key)
+
+    return true, nil
+}
+
+// 清空
+func (this *Memory) Flush() (bool, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.items = make(map[string]item)
+
+    return true, nil
+}
+
+// 不存在时设置
+func (this *Memory) Add(key string, value any, ttl time.Duration) (bool, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.sweep()
+
+    if _, ok := this.get(key); ok {
+        return false, nil
+    }
+
+    this.items[key] = newItem(value, ttl)
+
+    return true, nil
+}
+
+// 值相等时删除
+func (this *Memory) CompareAndForget(key string, value any) (bool, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    it, ok := this.get(key)
+    if !ok || fmt.Sprintf("%v", it.value) != fmt.Sprintf("%v", value) {
+        return false, nil
+    }
+
+    delete(this.items, key)
+
+    return true, nil
+}
+
+// 计数加一并返回计数，计数不存在时创建并设置过期时间
+func (this *Memory) Hit(key string, ttl time.Duration) (int64, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.sweep()
+
+    it, ok := this.get(key)
+    if !ok {
+        it = newItem(int64(0), ttl)
+    }
+
+    count := goch.ToInt64(it.value) + 1
+
+    it.value = count
+    this.items[key] = it
+
+    return count, nil
+}
+
+// 滑动窗口计数，上个窗口计数乘以权重加上当前窗口计数后还可以加一时当前窗口计数加一
+func (this *Memory) HitSliding(
+    previousKey string,
+    currentKey string,
+    weight float64,
+    max int64,
+    ttl time.Duration,
+) (int64, int64, bool, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.sweep()
+
+    var previous int64
+    if it, ok := this.get(previousKey); ok {
+        previous = goch.ToInt64(it.value)
+    }
+
+    it, ok := this.get(currentKey)
+    if !ok {
+        it = newItem(int64(0), ttl)
+    }
+
+    current := goch.ToInt64(it.value)
+    if float64(previous) * weight + float64(current + 1) > float64(max) {
+        return previous, current, false, nil
+    }
+
+    current++
+
+    it.value = current
+    this.items[currentKey] = it
+
+    return previous, current, true, nil
+}
+
+// 自增，保留原有过期时间
+func (this *Memory) incr(key string, step int64) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.sweep()
+
+    it, ok := this.get(key)
+    if !ok {
+        this.items[key] = item{value: step}
+        return nil
+    }
+
+    it.value = goch.ToInt64(it.value) + step
+    this.items[key] = it
+
+    return nil
+}
+
+// 获取未过期的数据，过期时删除
+func (this *Memory) get(key string) (item, bool) {
+    it, ok := this.items[key]
+    if !ok {
+        return it, false
+    }
+
+    if it.expired(time.Now()) {
+        delete(this.items, key)
+        return it, false
+    }
+
+    return it, true
+}
+
+// 到达清理时间时删除全部过期数据
+func (this *Memory) sweep() {
+    now := time.Now()
+    if now.Before(this.nextSweep) {
+        return
+    }
+
+    for key, it := range this.items {
+        if it.expired(now) {
+            delete(this.items, key)
+        }
+    }
+
+    this.nextSweep = now.Add(this.sweepInterval)
+}
+
+// 数据
+func newItem(value any, ttl time.Duration) item {
+    it := item{
+        value: value,
+    }
+
+    if ttl > 0 {
+        it.expiration = time.Now().Add(ttl)
+    }
+
+    return it
+}
@@ -0,0 +1,58 @@
+package memory
+
+import (
+    "time"
+    "testing"
+    "reflect"
+)
+
+func assertT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func Test_SweepExpired(t *testing.T) {
+    assert := assertT(t)
+
+    m := New().WithSweepInterval(10 * time.Millisecond)
+
+    m.Put("a", 1, 5 * time.Millisecond)
+    m.Hit("throttle:1", 5 * time.Millisecond)
+    m.Forever("b", 2)
+
+    assert(len(m.items), 3, "Items before expired")
+
+    time.Sleep(20 * time.Millisecond)
+
+    // 没有读取的过期数据在写入时删除
+    m.Put("c", 3, time.Minute)
+
+    _, ok := m.items["a"]
+    assert(ok, false, "Expired item removed")
+
+    _, ok = m.items["throttle:1"]
+    assert(ok, false, "Expired counter removed")
+
+    assert(len(m.items), 2, "Items after sweep")
+}
+
+func Test_SweepInterval(t *testing.T) {
+    assert := assertT(t)
+
+    m := New().WithSweepInterval(time.Hour)
+
+    m.Put("a", 1, time.Millisecond)
+
+    time.Sleep(5 * time.Millisecond)
+
+    // 未到清理时间时不清理
+    m.Put("b", 2, 0)
+    assert(len(m.items), 2, "Items before interval")
+
+    // 读取时删除过期数据
+    assert(m.Exists("a"), false, "Exists expired")
+    assert(len(m.items), 1, "Items after get")
+}
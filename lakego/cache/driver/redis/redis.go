@@ -9,6 +9,37 @@ import (
     "github.com/go-redis/redis/extra/redisotel/v8"
 )
 
+// 值相等时删除
+var compareAndForgetScript = redis.NewScript(`
+if redis.call('get', KEYS[1]) == ARGV[1] then
+    return redis.call('del', KEYS[1])
+end
+return 0
+`)
+
+// 计数加一，新建时设置过期时间
+var hitScript = redis.NewScript(`
+local count = redis.call('incr', KEYS[1])
+if count == 1 then
+    redis.call('pexpire', KEYS[1], ARGV[1])
+end
+return count
+`)
+
+// 滑动窗口计数，估算后还可以加一时计数加一
+var hitSlidingScript = redis.NewScript(`
+local previous = tonumber(redis.call('get', KEYS[1]) or '0')
+local current = tonumber(redis.call('get', KEYS[2]) or '0')
+if previous * tonumber(ARGV[1]) + current + 1 > tonumber(ARGV[2]) then
+    return {previous, current, 0}
+end
+current = redis.call('incr', KEYS[2])
+if current == 1 then
+    redis.call('pexpire', KEYS[2], ARGV[3])
+end
+return {previous, current, 1}
+`)
+
 // 日志接口
 type iLogger interface {
     Errorf(template string, args ...any)
@@ -184,6 +215,50 @@ func (this *Redis) Flush() (bool, error) {
     return true, nil
 }
 
+// 不存在时设置
+func (this *Redis) Add(key string, value any, ttl time.Duration) (bool, error) {
+    return this.client.SetNX(this.ctx, key, value, ttl).Result()
+}
+
+// 值相等时删除
+func (this *Redis) CompareAndForget(key string, value any) (bool, error) {
+    n, err := compareAndForgetScript.Run(this.ctx, this.client, []string{key}, value).Int()
+    if err != nil {
+        return false, err
+    }
+
+    return n > 0, nil
+}
+
+// 计数加一并返回计数，计数不存在时创建并设置过期时间
+func (this *Redis) Hit(key string, ttl time.Duration) (int64, error) {
+    return hitScript.Run(this.ctx, this.client, []string{key}, ttl.Milliseconds()).Int64()
+}
+
+// 滑动窗口计数，上个窗口计数乘以权重加上当前窗口计数后还可以加一时当前窗口计数加一
+func (this *Redis) HitSliding(
+    previousKey string,
+    currentKey string,
+    weight float64,
+    max int64,
+    ttl time.Duration,
+) (int64, int64, bool, error) {
+    res, err := hitSlidingScript.Run(
+        this.ctx, this.client,
+        []string{previousKey, currentKey},
+        weight, max, ttl.Milliseconds(),
+    ).Int64Slice()
+    if err != nil {
+        return 0, 0, false, err
+    }
+
+    if len(res) != 3 {
+        return 0, 0, false, errors.New("redis: hit sliding result error")
+    }
+
+    return res[0], res[1], res[2] == 1, nil
+}
+
 // HashSet
 func (this *Redis) HashSet(key string, field string, value string) error {
     return this.client.HSet(this.ctx, key, field, value).Err()
@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+    "time"
+)
+
+/**
+ * 计数驱动接口，用于限流等需要原子计数的场景
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type CounterDriver interface {
+    // 计数加一并返回计数，计数不存在时创建并设置过期时间
+    Hit(key string, ttl time.Duration) (int64, error)
+
+    // 滑动窗口计数，上个窗口计数乘以权重加上当前窗口计数后还可以加一时当前窗口计数加一
+    // 返回上个窗口计数，当前窗口计数及是否计数成功
+    HitSliding(previousKey string, currentKey string, weight float64, max int64, ttl time.Duration) (int64, int64, bool, error)
+}
@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+    "time"
+)
+
+/**
+ * 锁驱动接口
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type LockDriver interface {
+    // 不存在时存储，返回是否存储成功
+    Add(key string, value any, ttl time.Duration) (bool, error)
+
+    // 值相等时删除，返回是否删除成功
+    CompareAndForget(key string, value any) (bool, error)
+}
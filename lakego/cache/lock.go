@@ -0,0 +1,116 @@
+package cache
+
+import (
+    "fmt"
+    "time"
+
+    "github.com/deatil/lakego-doak/lakego/uuid"
+    "github.com/deatil/lakego-doak/lakego/cache/interfaces"
+)
+
+/**
+ * 缓存锁
+ *
+ * lock := cache.Lock("report", 10 * time.Minute)
+ * if ok, _ := lock.Get(); ok {
+ *     defer lock.Release()
+ * }
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Lock struct {
+    // 缓存
+    cache *Cache
+
+    // 锁名称
+    name string
+
+    // 持有者
+    owner string
+
+    // 过期时间
+    ttl time.Duration
+}
+
+// 创建锁，不传 owner 时随机生成
+func (this *Cache) Lock(name string, ttl time.Duration, owner ...string) *Lock {
+    lock := &Lock{
+        cache: this,
+        name:  name,
+        ttl:   ttl,
+    }
+
+    if len(owner) > 0 && owner[0] != "" {
+        lock.owner = owner[0]
+    } else {
+        lock.owner = uuid.ToUUIDString()
+    }
+
+    return lock
+}
+
+// 获取锁，已被占用时返回 false
+// 驱动没有实现锁接口时使用非原子的判断及存储
+func (this *Lock) Get() (bool, error) {
+    key := this.key()
+
+    if driver, ok := this.cache.driver.(interfaces.LockDriver); ok {
+        return driver.Add(key, this.owner, this.ttl)
+    }
+
+    if this.cache.driver.Exists(key) {
+        return false, nil
+    }
+
+    if err := this.cache.driver.Put(key, this.owner, this.ttl); err != nil {
+        return false, err
+    }
+
+    return true, nil
+}
+
+// 释放锁，只释放自己持有的锁
+func (this *Lock) Release() (bool, error) {
+    key := this.key()
+
+    if driver, ok := this.cache.driver.(interfaces.LockDriver); ok {
+        return driver.CompareAndForget(key, this.owner)
+    }
+
+    if this.CurrentOwner() != this.owner {
+        return false, nil
+    }
+
+    return this.cache.driver.Forget(key)
+}
+
+// 强制释放锁
+func (this *Lock) ForceRelease() (bool, error) {
+    return this.cache.driver.Forget(this.key())
+}
+
+// 当前持有者，没有被占用时为空
+func (this *Lock) CurrentOwner() string {
+    value, err := this.cache.driver.Get(this.key())
+    if err != nil || value == nil {
+        return ""
+    }
+
+    return fmt.Sprintf("%v", value)
+}
+
+// 持有者
+func (this *Lock) Owner() string {
+    return this.owner
+}
+
+// 锁名称
+func (this *Lock) Name() string {
+    return this.name
+}
+
+// 缓存键名
+func (this *Lock) key() string {
+    return this.cache.wrapperKey("lock:" + this.name)
+}
@@ -0,0 +1,167 @@
+package command
+
+import (
+    "io"
+    "sync"
+    "errors"
+    "context"
+    "strings"
+
+    "github.com/spf13/pflag"
+)
+
+// 脚本锁，同一个脚本共用参数变量，依次执行，不同脚本可以同时执行
+var runLocks sync.Map
+
+// 在当前进程执行根脚本下的子脚本，不会执行根脚本的 PersistentPreRun
+// ctx 通过 cmd.Context() 传给脚本，脚本需要处理 cmd.Context() 的取消，
+// 否则 ctx 结束后只能等待脚本执行完成
+// command.RunSubCommand(ctx, root, "cache:clear --store=redis", os.Stdout)
+func RunSubCommand(ctx context.Context, root *Command, line string, out io.Writer) error {
+    args, err := SplitArgs(line)
+    if err != nil {
+        return err
+    }
+
+    if len(args) == 0 {
+        return errors.New("command: empty command")
+    }
+
+    cmd, rest, err := root.Find(args)
+    if err != nil {
+        return err
+    }
+
+    if cmd == root {
+        return errors.New("command: unknown command \"" + args[0] + "\"")
+    }
+
+    lock, _ := runLocks.LoadOrStore(cmd, &sync.Mutex{})
+    lock.(*sync.Mutex).Lock()
+    defer lock.(*sync.Mutex).Unlock()
+
+    // 重置为默认值，避免上次执行的参数残留
+    resetFlags(cmd.Flags())
+
+    if err := cmd.ParseFlags(rest); err != nil {
+        return err
+    }
+
+    argv := cmd.Flags().Args()
+    if err := cmd.ValidateArgs(argv); err != nil {
+        return err
+    }
+
+    if out != nil {
+        cmd.SetOut(out)
+        cmd.SetErr(out)
+
+        defer func() {
+            cmd.SetOut(nil)
+            cmd.SetErr(nil)
+        }()
+    }
+
+    cmd.SetContext(ctx)
+    defer cmd.SetContext(nil)
+
+    if err := ctx.Err(); err != nil {
+        return err
+    }
+
+    if cmd.PreRunE != nil {
+        if err := cmd.PreRunE(cmd, argv); err != nil {
+            return err
+        }
+    } else if cmd.PreRun != nil {
+        cmd.PreRun(cmd, argv)
+    }
+
+    if err := ctx.Err(); err != nil {
+        return err
+    }
+
+    if cmd.RunE != nil {
+        if err := cmd.RunE(cmd, argv); err != nil {
+            return err
+        }
+    } else if cmd.Run != nil {
+        cmd.Run(cmd, argv)
+    } else {
+        return errors.New("command: \"" + cmd.Name() + "\" is not runnable")
+    }
+
+    if cmd.PostRunE != nil {
+        return cmd.PostRunE(cmd, argv)
+    } else if cmd.PostRun != nil {
+        cmd.PostRun(cmd, argv)
+    }
+
+    return nil
+}
+
+// 重置参数为默认值
+func resetFlags(flags *pflag.FlagSet) {
+    flags.VisitAll(func(flag *pflag.Flag) {
+        if slice, ok := flag.Value.(pflag.SliceValue); ok {
+            slice.Replace(nil)
+        } else {
+            flag.Value.Set(flag.DefValue)
+        }
+
+        flag.Changed = false
+    })
+}
+
+// 拆分命令行参数，支持单引号、双引号及反斜杠转义
+func SplitArgs(line string) ([]string, error) {
+    args := make([]string, 0)
+
+    var b strings.Builder
+    var quote rune
+    var escaped, inArg bool
+
+    for _, r := range line {
+        switch {
+            case escaped:
+                b.WriteRune(r)
+                escaped = false
+
+            case r == '\\' && quote != '\'':
+                escaped = true
+                inArg = true
+
+            case quote != 0:
+                if r == quote {
+                    quote = 0
+                } else {
+                    b.WriteRune(r)
+                }
+
+            case r == '\'' || r == '"':
+                quote = r
+                inArg = true
+
+            case r == ' ' || r == '\t' || r == '\n':
+                if inArg {
+                    args = append(args, b.String())
+                    b.Reset()
+                    inArg = false
+                }
+
+            default:
+                b.WriteRune(r)
+                inArg = true
+        }
+    }
+
+    if quote != 0 || escaped {
+        return nil, errors.New("command: unterminated quote or escape")
+    }
+
+    if inArg {
+        args = append(args, b.String())
+    }
+
+    return args, nil
+}
@@ -0,0 +1,145 @@
+package command
+
+import (
+    "time"
+    "bytes"
+    "context"
+    "testing"
+    "reflect"
+)
+
+func assertT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func Test_SplitArgs(t *testing.T) {
+    assert := assertT(t)
+
+    args, err := SplitArgs(`cache:clear --store=redis  -v`)
+    assert(err, nil, "SplitArgs error")
+    assert(args, []string{"cache:clear", "--store=redis", "-v"}, "SplitArgs spaces")
+
+    args, _ = SplitArgs(`send "hello world" 'it''s' a\ b ""`)
+    assert(args, []string{"send", "hello world", "its", "a b", ""}, "SplitArgs quotes")
+
+    args, _ = SplitArgs(`echo "say \"hi\"" 'no\escape'`)
+    assert(args, []string{"echo", `say "hi"`, `no\escape`}, "SplitArgs escape")
+
+    args, _ = SplitArgs("  ")
+    assert(args, []string{}, "SplitArgs empty")
+
+    _, err = SplitArgs(`echo "unterminated`)
+    assert(err != nil, true, "SplitArgs unterminated quote")
+
+    _, err = SplitArgs(`echo end\`)
+    assert(err != nil, true, "SplitArgs unterminated escape")
+}
+
+func Test_RunSubCommand(t *testing.T) {
+    assert := assertT(t)
+
+    var store string
+    var tags []string
+    var got []string
+
+    root := &Command{
+        Use: "root",
+        PersistentPreRun: func(*Command, []string) {
+            t.Error("Failed Test_RunSubCommand: root PersistentPreRun called")
+        },
+    }
+
+    sub := &Command{
+        Use: "cache:clear",
+        Run: func(cmd *Command, args []string) {
+            got = args
+            cmd.Printf("%s %v", store, tags)
+        },
+    }
+    sub.Flags().StringVar(&store, "store", "file", "")
+    sub.Flags().StringSliceVar(&tags, "tag", nil, "")
+
+    root.AddCommand(sub)
+
+    var out bytes.Buffer
+    err := RunSubCommand(context.Background(), root, "cache:clear --store=redis --tag a --tag b extra", &out)
+    assert(err, nil, "RunSubCommand error")
+    assert(store, "redis", "RunSubCommand flag")
+    assert(tags, []string{"a", "b"}, "RunSubCommand slice flag")
+    assert(got, []string{"extra"}, "RunSubCommand args")
+    assert(out.String(), "redis [a b]", "RunSubCommand output")
+
+    // 再次执行时参数重置为默认值
+    err = RunSubCommand(context.Background(), root, "cache:clear", &bytes.Buffer{})
+    assert(err, nil, "RunSubCommand again")
+    assert(store, "file", "RunSubCommand reset flag")
+    assert(len(tags), 0, "RunSubCommand reset slice flag")
+
+    err = RunSubCommand(context.Background(), root, "", nil)
+    assert(err != nil, true, "RunSubCommand empty")
+
+    err = RunSubCommand(context.Background(), root, "unknown", nil)
+    assert(err != nil, true, "RunSubCommand unknown")
+
+    err = RunSubCommand(context.Background(), root, "cache:clear --missing", nil)
+    assert(err != nil, true, "RunSubCommand unknown flag")
+
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+
+    got = nil
+    err = RunSubCommand(ctx, root, "cache:clear", nil)
+    assert(err, context.Canceled, "RunSubCommand canceled")
+    assert(got, []string(nil), "RunSubCommand canceled not run")
+}
+
+func Test_RunSubCommandContext(t *testing.T) {
+    assert := assertT(t)
+
+    root := &Command{Use: "root"}
+
+    started := make(chan struct{})
+    root.AddCommand(&Command{
+        Use: "wait",
+        RunE: func(cmd *Command, args []string) error {
+            close(started)
+
+            // 脚本通过 cmd.Context() 处理取消
+            <-cmd.Context().Done()
+            return cmd.Context().Err()
+        },
+    })
+
+    fast := &Command{
+        Use: "fast",
+        Run: func(cmd *Command, args []string) {},
+    }
+    root.AddCommand(fast)
+
+    ctx, cancel := context.WithCancel(context.Background())
+
+    errs := make(chan error, 1)
+    go func() {
+        errs <- RunSubCommand(ctx, root, "wait", nil)
+    }()
+
+    <-started
+
+    // 其他脚本不需要等待正在执行的脚本
+    assert(RunSubCommand(context.Background(), root, "fast", nil), nil, "RunSubCommand other command")
+
+    cancel()
+
+    select {
+        case err := <-errs:
+            assert(err, context.Canceled, "RunSubCommand context canceled")
+        case <-time.After(time.Second):
+            t.Fatal("Failed RunSubCommandContext: command not canceled")
+    }
+
+    assert(fast.Context(), nil, "RunSubCommand context reset")
+}
@@ -0,0 +1,196 @@
+package log
+
+import (
+    "io"
+    "os"
+    "fmt"
+    "bytes"
+    "bufio"
+    "errors"
+    "strings"
+    "compress/gzip"
+
+    "github.com/deatil/lakego-doak/lakego/path"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+    stackDriver "github.com/deatil/lakego-doak/lakego/logger/driver/stack"
+)
+
+// 默认输出到命令行的驱动
+var stdoutDrivers = []string{"console", "stdout-json"}
+
+// 通道的日志文件规则，stack 通道返回全部子通道的规则
+func channelPatterns(name string) ([]string, error) {
+    conf := config.New("logger")
+
+    if name == "" {
+        name = conf.GetString("default")
+    }
+
+    drivers := conf.GetStringMap("drivers")
+
+    driverConf, ok := drivers[strings.ToLower(name)].(map[string]any)
+    if !ok {
+        return nil, fmt.Errorf("日志通道[%s]配置不存在", name)
+    }
+
+    patterns := make([]string, 0)
+
+    driverType, _ := driverConf["type"].(string)
+    if driverType == "stack" {
+        for _, channel := range stackDriver.ParseChannels(driverConf) {
+            if channelConf, ok := drivers[strings.ToLower(channel.Name)].(map[string]any); ok {
+                if pattern := filePattern(channelConf); pattern != "" {
+                    patterns = append(patterns, pattern)
+                }
+            }
+        }
+    } else if pattern := filePattern(driverConf); pattern != "" {
+        patterns = append(patterns, pattern)
+    }
+
+    if len(patterns) == 0 {
+        return nil, fmt.Errorf("日志通道[%s]没有写入文件", name)
+    }
+
+    return patterns, nil
+}
+
+// 驱动配置的文件规则，不写入文件时返回空
+func filePattern(conf map[string]any) string {
+    driverType, _ := conf["type"].(string)
+    output, _ := conf["output"].(string)
+
+    if output == "" {
+        for _, typ := range stdoutDrivers {
+            if typ == driverType {
+                output = "stdout"
+            }
+        }
+    }
+
+    if output == "stdout" || output == "stderr" || driverType == "stack" {
+        return ""
+    }
+
+    filepath, _ := conf["filepath"].(string)
+    if filepath == "" {
+        return ""
+    }
+
+    return path.FormatPath(filepath)
+}
+
+// 打开日志文件，压缩文件自动解压
+func openFile(name string) (io.ReadCloser, error) {
+    file, err := os.Open(name)
+    if err != nil {
+        return nil, err
+    }
+
+    if !strings.HasSuffix(name, ".gz") {
+        return file, nil
+    }
+
+    reader, err := gzip.NewReader(file)
+    if err != nil {
+        file.Close()
+        return nil, err
+    }
+
+    return &gzipFile{reader, file}, nil
+}
+
+// 压缩文件
+type gzipFile struct {
+    *gzip.Reader
+
+    file *os.File
+}
+
+func (this *gzipFile) Close() error {
+    this.Reader.Close()
+    return this.file.Close()
+}
+
+// 逐行读取
+func eachLine(reader io.Reader, fn func(string)) error {
+    scanner := bufio.NewScanner(reader)
+    scanner.Buffer(make([]byte, 64 * 1024), 4 * 1024 * 1024)
+
+    for scanner.Scan() {
+        fn(scanner.Text())
+    }
+
+    return scanner.Err()
+}
+
+// 从文件末尾读取满足条件的最后 n 行
+func lastLines(name string, n int, match func(string) bool) ([]string, error) {
+    file, err := os.Open(name)
+    if err != nil {
+        return nil, err
+    }
+    defer file.Close()
+
+    info, err := file.Stat()
+    if err != nil {
+        return nil, err
+    }
+
+    const blockSize = 8 * 1024
+
+    var buf []byte
+    offset := info.Size()
+
+    for {
+        lines := splitLines(buf, offset == 0)
+
+        matched := make([]string, 0, n)
+        for i := len(lines) - 1; i >= 0 && len(matched) < n; i-- {
+            if match(lines[i]) {
+                matched = append(matched, lines[i])
+            }
+        }
+
+        if len(matched) >= n || offset == 0 {
+            // 倒序转为正序
+            for i, j := 0, len(matched) - 1; i < j; i, j = i + 1, j - 1 {
+                matched[i], matched[j] = matched[j], matched[i]
+            }
+
+            return matched, nil
+        }
+
+        size := int64(blockSize)
+        if offset < size {
+            size = offset
+        }
+        offset -= size
+
+        block := make([]byte, size)
+        if _, err := file.ReadAt(block, offset); err != nil && !errors.Is(err, io.EOF) {
+            return nil, err
+        }
+
+        buf = append(block, buf...)
+    }
+}
+
+// 拆分为行，不是文件开头时丢弃第一个不完整的行
+func splitLines(buf []byte, fromStart bool) []string {
+    if !fromStart {
+        index := bytes.IndexByte(buf, '\n')
+        if index < 0 {
+            return nil
+        }
+
+        buf = buf[index + 1:]
+    }
+
+    buf = bytes.TrimRight(buf, "\n")
+    if len(buf) == 0 {
+        return nil
+    }
+
+    return strings.Split(string(buf), "\n")
+}
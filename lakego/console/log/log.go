@@ -0,0 +1,386 @@
+package log
+
+import (
+    "io"
+    "os"
+    "fmt"
+    "time"
+    "regexp"
+    "context"
+    "syscall"
+    "os/signal"
+
+    "github.com/deatil/lakego-doak/lakego/color"
+    "github.com/deatil/lakego-doak/lakego/command"
+    "github.com/deatil/lakego-doak/lakego/logger/rotate"
+)
+
+// 文件检测间隔
+const followInterval = 500 * time.Millisecond
+
+/**
+ * 查看日志最新内容
+ *
+ * > ./main log:tail [--channel=daily] [--level=warning] [-n 20]
+ * > main.exe log:tail [--channel=daily] [--level=warning] [-n 20]
+ * > go run main.go log:tail [--channel=daily] [--level=warning] [-n 20]
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var LogTailCmd = &command.Command{
+    Use: "log:tail",
+    Short: "查看通道当前日志文件的最新内容并持续输出。",
+    Example: "{execfile} log:tail --channel=daily --level=warning -n 20",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+    },
+    RunE: func(cmd *command.Command, args []string) error {
+        return Tail(tailChannel, tailLevel, tailLines)
+    },
+}
+
+/**
+ * 搜索日志
+ *
+ * > ./main log:search [--channel=daily] [--from="2026-10-19 08:00:00"] [--to=2026-10-20] [--level=error] [--grep=timeout]
+ * > main.exe log:search [--channel=daily] [--from="2026-10-19 08:00:00"] [--to=2026-10-20] [--level=error] [--grep=timeout]
+ * > go run main.go log:search [--channel=daily] [--from="2026-10-19 08:00:00"] [--to=2026-10-20] [--level=error] [--grep=timeout]
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var LogSearchCmd = &command.Command{
+    Use: "log:search",
+    Short: "搜索通道的全部日志文件，包括切割及压缩的文件。",
+    Example: "{execfile} log:search --from=2026-10-19 --level=error --grep=timeout",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+    },
+    RunE: func(cmd *command.Command, args []string) error {
+        return Search(searchChannel, searchFrom, searchTo, searchLevel, searchGrep)
+    },
+}
+
+var (
+    // 通道
+    tailChannel string
+
+    // 最低等级
+    tailLevel string
+
+    // 行数
+    tailLines int
+)
+
+var (
+    // 通道
+    searchChannel string
+
+    // 开始时间
+    searchFrom string
+
+    // 结束时间
+    searchTo string
+
+    // 最低等级
+    searchLevel string
+
+    // 匹配内容
+    searchGrep string
+)
+
+func init() {
+    tf := LogTailCmd.Flags()
+    tf.StringVarP(&tailChannel, "channel", "c", "", "日志通道，默认为默认通道")
+    tf.StringVarP(&tailLevel, "level", "l", "", "最低日志等级")
+    tf.IntVarP(&tailLines, "lines", "n", 10, "先输出的行数")
+
+    sf := LogSearchCmd.Flags()
+    sf.StringVarP(&searchChannel, "channel", "c", "", "日志通道，默认为默认通道")
+    sf.StringVarP(&searchFrom, "from", "f", "", "开始时间")
+    sf.StringVarP(&searchTo, "to", "t", "", "结束时间")
+    sf.StringVarP(&searchLevel, "level", "l", "", "最低日志等级")
+    sf.StringVarP(&searchGrep, "grep", "g", "", "匹配内容，支持正则")
+}
+
+// 查看日志
+func Tail(channel string, level string, lines int) error {
+    patterns, err := channelPatterns(channel)
+    if err != nil {
+        return err
+    }
+
+    match := func(line string) bool {
+        return ParseRecord(line).MatchLevel(level)
+    }
+
+    followers := make([]*follower, 0, len(patterns))
+    for _, pattern := range patterns {
+        f := newFollower(pattern, match)
+        f.printLast(lines)
+
+        followers = append(followers, f)
+    }
+
+    ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+    defer stop()
+
+    ticker := time.NewTicker(followInterval)
+    defer ticker.Stop()
+
+    for {
+        select {
+            case <-ctx.Done():
+                for _, f := range followers {
+                    f.close()
+                }
+
+                return nil
+
+            case <-ticker.C:
+                for _, f := range followers {
+                    f.poll()
+                }
+        }
+    }
+}
+
+// 搜索日志
+func Search(channel string, from string, to string, level string, grep string) error {
+    patterns, err := channelPatterns(channel)
+    if err != nil {
+        return err
+    }
+
+    fromTime, err := parseFlagTime(from, false)
+    if err != nil {
+        return err
+    }
+
+    toTime, err := parseFlagTime(to, true)
+    if err != nil {
+        return err
+    }
+
+    var grepRegexp *regexp.Regexp
+    if grep != "" {
+        grepRegexp, err = regexp.Compile(grep)
+        if err != nil {
+            return fmt.Errorf("grep 格式错误：%s", err)
+        }
+    }
+
+    total := 0
+    for _, pattern := range patterns {
+        for _, name := range rotate.Files(pattern) {
+            // 最后写入时间早于开始时间的文件跳过
+            if !fromTime.IsZero() {
+                if info, err := os.Stat(name); err == nil && info.ModTime().Before(fromTime) {
+                    continue
+                }
+            }
+
+            file, err := openFile(name)
+            if err != nil {
+                color.Redln("打开文件失败：" + err.Error())
+                continue
+            }
+
+            err = eachLine(file, func(line string) {
+                if grepRegexp != nil && !grepRegexp.MatchString(line) {
+                    return
+                }
+
+                record := ParseRecord(line)
+                if !record.MatchLevel(level) || !record.MatchTime(fromTime, toTime) {
+                    return
+                }
+
+                total++
+                fmt.Println(record.String())
+            })
+            file.Close()
+
+            if err != nil {
+                color.Redln("读取文件失败：" + name + "，" + err.Error())
+            }
+        }
+    }
+
+    fmt.Print("\n")
+    color.Greenln(fmt.Sprintf("共找到 %d 条日志", total))
+
+    return nil
+}
+
+// 解析时间参数，只有日期的结束时间为当天结束
+func parseFlagTime(value string, end bool) (time.Time, error) {
+    if value == "" {
+        return time.Time{}, nil
+    }
+
+    t := parseTime(value)
+    if t.IsZero() {
+        return t, fmt.Errorf("时间[%s]格式错误，支持 2006-01-02 15:04:05 格式", value)
+    }
+
+    if end && len(value) == len("2006-01-02") {
+        t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+    }
+
+    return t, nil
+}
+
+// ==========
+
+/**
+ * 持续读取当前日志文件
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type follower struct {
+    // 文件规则
+    pattern string
+
+    // 过滤
+    match func(string) bool
+
+    // 当前文件
+    file *os.File
+
+    // 当前文件名称
+    name string
+
+    // 当前文件信息
+    info os.FileInfo
+
+    // 读取位置
+    offset int64
+
+    // 未完成的行
+    partial string
+}
+
+// 构造函数
+func newFollower(pattern string, match func(string) bool) *follower {
+    return &follower{
+        pattern: pattern,
+        match:   match,
+    }
+}
+
+// 输出最后几行，并从文件末尾开始读取
+func (this *follower) printLast(n int) {
+    name := rotate.FormatName(this.pattern, time.Now())
+
+    lines, err := lastLines(name, n, this.match)
+    if err != nil {
+        if !os.IsNotExist(err) {
+            color.Redln("读取文件失败：" + err.Error())
+        }
+
+        return
+    }
+
+    for _, line := range lines {
+        fmt.Println(ParseRecord(line).String())
+    }
+
+    if this.open(name) == nil {
+        this.offset = this.info.Size()
+    }
+}
+
+// 检测文件变化并输出新内容
+func (this *follower) poll() {
+    name := rotate.FormatName(this.pattern, time.Now())
+
+    if this.file == nil {
+        this.open(name)
+        return
+    }
+
+    this.read()
+
+    // 按时间切割后文件名称变化，或按大小切割后文件被重命名
+    info, err := os.Stat(name)
+    if name != this.name || (err == nil && !os.SameFile(info, this.info)) {
+        this.close()
+        this.open(name)
+        this.read()
+        return
+    }
+
+    // 文件被清空
+    if err == nil && info.Size() < this.offset {
+        this.offset = 0
+        this.partial = ""
+        this.read()
+    }
+}
+
+// 打开文件
+func (this *follower) open(name string) error {
+    file, err := os.Open(name)
+    if err != nil {
+        return err
+    }
+
+    info, err := file.Stat()
+    if err != nil {
+        file.Close()
+        return err
+    }
+
+    this.file = file
+    this.name = name
+    this.info = info
+    this.offset = 0
+    this.partial = ""
+
+    return nil
+}
+
+// 读取新内容
+func (this *follower) read() {
+    if this.file == nil {
+        return
+    }
+
+    if _, err := this.file.Seek(this.offset, io.SeekStart); err != nil {
+        return
+    }
+
+    data, err := io.ReadAll(this.file)
+    if err != nil || len(data) == 0 {
+        return
+    }
+
+    this.offset += int64(len(data))
+
+    content := this.partial + string(data)
+    lines := splitLines([]byte(content), true)
+
+    // 最后一行没有换行时等待后续内容
+    this.partial = ""
+    if content[len(content) - 1] != '\n' && len(lines) > 0 {
+        this.partial = lines[len(lines) - 1]
+        lines = lines[:len(lines) - 1]
+    }
+
+    for _, line := range lines {
+        if this.match(line) {
+            fmt.Println(ParseRecord(line).String())
+        }
+    }
+}
+
+// 关闭文件
+func (this *follower) close() {
+    if this.file != nil {
+        this.file.Close()
+        this.file = nil
+    }
+}
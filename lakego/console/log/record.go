@@ -0,0 +1,254 @@
+package log
+
+import (
+    "fmt"
+    "sort"
+    "time"
+    "regexp"
+    "strings"
+    "encoding/json"
+
+    "github.com/deatil/lakego-doak/lakego/color"
+    "github.com/deatil/lakego-doak/lakego/logger"
+)
+
+// 等级颜色
+var levelColors = map[string]string{
+    "panic":   "red",
+    "fatal":   "red",
+    "error":   "red",
+    "warning": "yellow",
+    "info":    "green",
+    "debug":   "cyan",
+    "trace":   "white",
+}
+
+// json 格式的默认字段
+var (
+    timeKeys    = []string{"timestamp", "time"}
+    levelKeys   = []string{"level"}
+    messageKeys = []string{"message", "msg"}
+)
+
+// 文本格式时间
+var timeLayouts = []string{
+    time.RFC3339Nano,
+    "2006-01-02 15:04:05",
+    "2006-01-02T15:04:05",
+    "2006-01-02",
+}
+
+var (
+    // 命令行颜色
+    ansiRegexp = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+    // normal 及 console 格式
+    // [2026-10-19 12:00:00] [info] message
+    lineRegexp = regexp.MustCompile(`^\[?(\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2})\]? \[(\w+)\] ?(.*)$`)
+
+    // text 格式
+    // time="2026-10-19 12:00:00" level=info msg="message"
+    textTimeRegexp  = regexp.MustCompile(`time="([^"]+)"`)
+    textLevelRegexp = regexp.MustCompile(`level=(\w+)`)
+    textMsgRegexp   = regexp.MustCompile(`msg="((?:[^"\\]|\\.)*)"|msg=(\S+)`)
+)
+
+/**
+ * 单条日志
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Record struct {
+    // 时间
+    Time time.Time
+
+    // 等级
+    Level string
+
+    // 信息
+    Message string
+
+    // 自定义数据
+    Fields map[string]any
+
+    // 原始数据
+    Raw string
+
+    // 是否为 json 格式
+    IsJSON bool
+}
+
+// 解析日志
+func ParseRecord(line string) Record {
+    record := Record{
+        Raw: line,
+    }
+
+    trimmed := strings.TrimSpace(ansiRegexp.ReplaceAllString(line, ""))
+
+    // json 格式
+    if strings.HasPrefix(trimmed, "{") {
+        data := make(map[string]any)
+        if err := json.Unmarshal([]byte(trimmed), &data); err == nil {
+            record.IsJSON = true
+            record.Time = parseTime(takeString(data, timeKeys))
+            record.Level = normalizeLevel(takeString(data, levelKeys))
+            record.Message = takeString(data, messageKeys)
+            record.Fields = data
+
+            return record
+        }
+    }
+
+    if match := lineRegexp.FindStringSubmatch(trimmed); match != nil {
+        record.Time = parseTime(match[1])
+        record.Level = normalizeLevel(match[2])
+        record.Message = match[3]
+
+        return record
+    }
+
+    if match := textLevelRegexp.FindStringSubmatch(trimmed); match != nil {
+        record.Level = normalizeLevel(match[1])
+
+        if t := textTimeRegexp.FindStringSubmatch(trimmed); t != nil {
+            record.Time = parseTime(t[1])
+        }
+
+        if msg := textMsgRegexp.FindStringSubmatch(trimmed); msg != nil {
+            record.Message = msg[1] + msg[2]
+        }
+    }
+
+    return record
+}
+
+// 是否满足最低等级
+func (this Record) MatchLevel(level string) bool {
+    if level == "" {
+        return true
+    }
+
+    if this.Level == "" {
+        return false
+    }
+
+    return logger.ParseLevel(level).Enabled(logger.ParseLevel(this.Level))
+}
+
+// 是否在时间范围内
+func (this Record) MatchTime(from time.Time, to time.Time) bool {
+    if from.IsZero() && to.IsZero() {
+        return true
+    }
+
+    if this.Time.IsZero() {
+        return false
+    }
+
+    if !from.IsZero() && this.Time.Before(from) {
+        return false
+    }
+
+    if !to.IsZero() && this.Time.After(to) {
+        return false
+    }
+
+    return true
+}
+
+// 输出
+func (this Record) String() string {
+    if !this.IsJSON {
+        if this.Level == "" || ansiRegexp.MatchString(this.Raw) {
+            return this.Raw
+        }
+
+        return paint(levelColors[this.Level], this.Raw)
+    }
+
+    var b strings.Builder
+
+    if !this.Time.IsZero() {
+        b.WriteString(paint("white", this.Time.Format("2006-01-02 15:04:05")))
+        b.WriteString(" ")
+    }
+
+    b.WriteString(paint(levelColors[this.Level], fmt.Sprintf("[%s]", this.Level)))
+    b.WriteString(" ")
+    b.WriteString(this.Message)
+
+    keys := make([]string, 0, len(this.Fields))
+    for k, _ := range this.Fields {
+        keys = append(keys, k)
+    }
+    sort.Strings(keys)
+
+    for _, k := range keys {
+        b.WriteString("\n    ")
+        b.WriteString(paint("cyan", k))
+        b.WriteString(": ")
+        b.WriteString(formatValue(this.Fields[k]))
+    }
+
+    return b.String()
+}
+
+// 设置颜色
+func paint(name string, msg string) string {
+    if name == "" {
+        return msg
+    }
+
+    return color.New(color.ForegroundOption(name)).Sprint(msg)
+}
+
+// 格式化数据
+func formatValue(value any) string {
+    switch v := value.(type) {
+        case string:
+            return v
+
+        case map[string]any, []any:
+            data, err := json.MarshalIndent(v, "    ", "  ")
+            if err == nil {
+                return string(data)
+            }
+    }
+
+    return fmt.Sprintf("%v", value)
+}
+
+// 取出字段，取出后从数据中删除
+func takeString(data map[string]any, keys []string) string {
+    for _, key := range keys {
+        if value, ok := data[key]; ok {
+            delete(data, key)
+            return fmt.Sprintf("%v", value)
+        }
+    }
+
+    return ""
+}
+
+// 统一等级名称
+func normalizeLevel(level string) string {
+    level = strings.ToLower(level)
+    if level == "" {
+        return ""
+    }
+
+    return logger.ParseLevel(level).String()
+}
+
+// 解析时间
+func parseTime(value string) time.Time {
+    for _, layout := range timeLayouts {
+        if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+            return t
+        }
+    }
+
+    return time.Time{}
+}
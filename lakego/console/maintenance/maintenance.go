@@ -0,0 +1,112 @@
+package maintenance
+
+import (
+    "fmt"
+
+    "github.com/deatil/lakego-doak/lakego/color"
+    "github.com/deatil/lakego-doak/lakego/command"
+    "github.com/deatil/lakego-doak/lakego/maintenance"
+)
+
+/**
+ * 开启维护模式
+ *
+ * > ./main down [--secret=token] [--retry=60] [--allow=127.0.0.1,10.0.0.0/8] [--render=errors/503]
+ * > main.exe down [--secret=token] [--retry=60] [--allow=127.0.0.1,10.0.0.0/8] [--render=errors/503]
+ * > go run main.go down [--secret=token] [--retry=60] [--allow=127.0.0.1,10.0.0.0/8] [--render=errors/503]
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var DownCmd = &command.Command{
+    Use: "down",
+    Short: "开启维护模式。",
+    Example: "{execfile} down --secret=token --retry=60 --allow=127.0.0.1",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+    },
+    RunE: func(cmd *command.Command, args []string) error {
+        return Down(downSecret, downRetry, downAllow, downRender)
+    },
+}
+
+/**
+ * 结束维护模式
+ *
+ * > ./main up
+ * > main.exe up
+ * > go run main.go up
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var UpCmd = &command.Command{
+    Use: "up",
+    Short: "结束维护模式。",
+    Example: "{execfile} up",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+    },
+    RunE: func(cmd *command.Command, args []string) error {
+        return Up()
+    },
+}
+
+var (
+    // 跳过维护的密钥
+    downSecret string
+
+    // 重试时间
+    downRetry int
+
+    // 允许访问的 IP
+    downAllow []string
+
+    // 维护页面模板
+    downRender string
+)
+
+func init() {
+    pf := DownCmd.Flags()
+    pf.StringVarP(&downSecret, "secret", "s", "", "跳过维护的密钥，访问 /<secret> 后可以正常访问")
+    pf.IntVarP(&downRetry, "retry", "r", 0, "重试时间，单位为秒")
+    pf.StringSliceVarP(&downAllow, "allow", "a", nil, "允许访问的 IP，支持 CIDR，多个使用逗号分隔")
+    pf.StringVarP(&downRender, "render", "", "", "维护页面模板")
+}
+
+// 开启维护模式
+func Down(secret string, retry int, allow []string, render string) error {
+    err := maintenance.Down(maintenance.Payload{
+        Secret: secret,
+        Retry:  retry,
+        Allow:  allow,
+        Render: render,
+    })
+    if err != nil {
+        return fmt.Errorf("开启维护模式失败：%s", err.Error())
+    }
+
+    color.Yellowln("已开启维护模式")
+
+    if secret != "" {
+        color.Greenln("跳过维护链接：/" + secret)
+    }
+
+    return nil
+}
+
+// 结束维护模式
+func Up() error {
+    if !maintenance.IsDown() {
+        color.Greenln("当前不在维护模式")
+        return nil
+    }
+
+    if err := maintenance.Up(); err != nil {
+        return fmt.Errorf("结束维护模式失败：%s", err.Error())
+    }
+
+    color.Greenln("已结束维护模式")
+
+    return nil
+}
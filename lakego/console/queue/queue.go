@@ -0,0 +1,315 @@
+package queue
+
+import (
+    "os"
+    "fmt"
+    "time"
+    "errors"
+    "context"
+    "strings"
+    "syscall"
+    "os/signal"
+    "text/tabwriter"
+
+    "github.com/deatil/lakego-doak/lakego/color"
+    "github.com/deatil/lakego-doak/lakego/command"
+    "github.com/deatil/lakego-doak/lakego/queue"
+    "github.com/deatil/lakego-doak/lakego/queue/interfaces"
+    queueFacade "github.com/deatil/lakego-doak/lakego/facade/queue"
+)
+
+/**
+ * 执行队列任务
+ *
+ * > ./main queue:work [--connection=redis] [--queue=high,default] [--concurrency=4] [--max-jobs=1000] [--max-time=1h]
+ * > main.exe queue:work [--connection=redis] [--queue=high,default] [--concurrency=4] [--max-jobs=1000] [--max-time=1h]
+ * > go run main.go queue:work [--connection=redis] [--queue=high,default] [--concurrency=4] [--max-jobs=1000] [--max-time=1h]
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var QueueWorkCmd = &command.Command{
+    Use: "queue:work",
+    Short: "执行队列任务，收到 SIGTERM 后停止取出任务，等待正在执行的任务完成再退出，再次收到时强制退出。",
+    Example: "{execfile} queue:work --queue=high,default --concurrency=4",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+    },
+    RunE: func(cmd *command.Command, args []string) error {
+        return Work(workConnection, workQueue, workConcurrency, workMaxJobs, workMaxTime, workSleep)
+    },
+}
+
+/**
+ * 失败的任务列表
+ *
+ * > ./main queue:failed
+ * > main.exe queue:failed
+ * > go run main.go queue:failed
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var QueueFailedCmd = &command.Command{
+    Use: "queue:failed",
+    Short: "查看失败的队列任务。",
+    Example: "{execfile} queue:failed",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+    },
+    RunE: func(cmd *command.Command, args []string) error {
+        return Failed()
+    },
+}
+
+/**
+ * 重新推送失败的任务
+ *
+ * > ./main queue:retry <id|all>
+ * > main.exe queue:retry <id|all>
+ * > go run main.go queue:retry <id|all>
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var QueueRetryCmd = &command.Command{
+    Use: "queue:retry <id|all>",
+    Short: "重新推送失败的队列任务。",
+    Example: "{execfile} queue:retry all",
+    Args: command.ExactArgs(1),
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+    },
+    RunE: func(cmd *command.Command, args []string) error {
+        return Retry(args[0])
+    },
+}
+
+/**
+ * 清空失败的任务
+ *
+ * > ./main queue:flush
+ * > main.exe queue:flush
+ * > go run main.go queue:flush
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var QueueFlushCmd = &command.Command{
+    Use: "queue:flush",
+    Short: "清空失败的队列任务。",
+    Example: "{execfile} queue:flush",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+    },
+    RunE: func(cmd *command.Command, args []string) error {
+        return Flush()
+    },
+}
+
+var (
+    // 连接名称
+    workConnection string
+
+    // 队列名称
+    workQueue string
+
+    // 并发数量
+    workConcurrency int
+
+    // 最多执行的任务数量
+    workMaxJobs int64
+
+    // 最长运行时间
+    workMaxTime time.Duration
+
+    // 没有任务时的等待时间
+    workSleep time.Duration
+)
+
+func init() {
+    pf := QueueWorkCmd.Flags()
+    pf.StringVarP(&workConnection, "connection", "c", "", "队列连接，默认为默认连接")
+    pf.StringVarP(&workQueue, "queue", "q", "", "队列名称，多个用逗号分隔，按顺序优先执行")
+    pf.IntVar(&workConcurrency, "concurrency", 1, "并发数量")
+    pf.Int64Var(&workMaxJobs, "max-jobs", 0, "执行指定数量的任务后退出，0 为不限制")
+    pf.DurationVar(&workMaxTime, "max-time", 0, "运行指定时间后退出，0 为不限制")
+    pf.DurationVar(&workSleep, "sleep", queue.DefaultSleep, "没有任务时的等待时间")
+}
+
+// 执行队列任务
+func Work(
+    connection string,
+    queues string,
+    concurrency int,
+    maxJobs int64,
+    maxTime time.Duration,
+    sleep time.Duration,
+) error {
+    if connection == "" {
+        connection = queueFacade.GetDefaultConnection()
+    }
+
+    q := queueFacade.Connection(connection)
+    if q.IsSync() {
+        return errors.New("同步驱动推送时直接执行任务，不需要执行队列")
+    }
+
+    names := make([]string, 0)
+    for _, name := range strings.Split(queues, ",") {
+        if name = strings.TrimSpace(name); name != "" {
+            names = append(names, name)
+        }
+    }
+
+    worker := queue.NewWorker(q, names...).
+        WithConcurrency(concurrency).
+        WithMaxJobs(maxJobs).
+        WithMaxTime(maxTime).
+        WithSleep(sleep)
+
+    ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+    defer stop()
+
+    // 收到信号后恢复默认处理，再次收到时强制退出
+    go func() {
+        <-ctx.Done()
+        stop()
+    }()
+
+    color.Greenln(fmt.Sprintf(
+        "[%s] 队列连接[%s]开始执行，队列：%s，并发数量：%d",
+        time.Now().Format("2006-01-02 15:04:05"),
+        connection,
+        strings.Join(worker.GetQueues(), ","),
+        concurrency,
+    ))
+
+    worker.Run(ctx)
+
+    color.Greenln(fmt.Sprintf(
+        "[%s] 队列已停止，共执行 %d 个任务",
+        time.Now().Format("2006-01-02 15:04:05"),
+        worker.Processed(),
+    ))
+
+    return nil
+}
+
+// 失败的任务列表
+func Failed() error {
+    store, err := failedStore()
+    if err != nil {
+        return err
+    }
+
+    jobs, err := store.All(context.Background())
+    if err != nil {
+        return err
+    }
+
+    if len(jobs) == 0 {
+        color.Greenln("没有失败的任务")
+        return nil
+    }
+
+    w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+    fmt.Fprintln(w, "ID\t连接\t队列\t任务\t失败时间\t错误")
+
+    for _, job := range jobs {
+        fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+            job.ID,
+            job.Connection,
+            job.Queue,
+            job.Job,
+            job.FailedAt.Format("2006-01-02 15:04:05"),
+            firstLine(job.Exception),
+        )
+    }
+
+    return w.Flush()
+}
+
+// 重新推送失败的任务
+func Retry(id string) error {
+    store, err := failedStore()
+    if err != nil {
+        return err
+    }
+
+    ctx := context.Background()
+
+    var jobs []*interfaces.FailedJob
+    if id == "all" {
+        jobs, err = store.All(ctx)
+        if err != nil {
+            return err
+        }
+    } else {
+        job, err := store.Find(ctx, id)
+        if err != nil {
+            return err
+        }
+
+        if job == nil {
+            return fmt.Errorf("失败的任务[%s]不存在", id)
+        }
+
+        jobs = append(jobs, job)
+    }
+
+    for _, job := range jobs {
+        connection := job.Connection
+        if connection == "" {
+            connection = queueFacade.GetDefaultConnection()
+        }
+
+        if err := queueFacade.Connection(connection).Retry(ctx, job); err != nil {
+            color.Redln(fmt.Sprintf("任务[%s]重新推送失败：%s", job.ID, err.Error()))
+            continue
+        }
+
+        color.Greenln(fmt.Sprintf("任务[%s]已重新推送", job.ID))
+    }
+
+    return nil
+}
+
+// 清空失败的任务
+func Flush() error {
+    store, err := failedStore()
+    if err != nil {
+        return err
+    }
+
+    if err := store.Flush(context.Background()); err != nil {
+        return err
+    }
+
+    color.Greenln("失败的任务已清空")
+
+    return nil
+}
+
+// 失败任务存储
+func failedStore() (interfaces.FailedStore, error) {
+    store := queueFacade.FailedStore()
+    if store == nil {
+        return nil, errors.New("没有配置失败任务存储")
+    }
+
+    return store, nil
+}
+
+// 第一行
+func firstLine(s string) string {
+    if i := strings.IndexByte(s, '\n'); i >= 0 {
+        s = s[:i]
+    }
+
+    if len([]rune(s)) > 80 {
+        s = string([]rune(s)[:80]) + "..."
+    }
+
+    return s
+}
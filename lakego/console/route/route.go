@@ -0,0 +1,181 @@
+package route
+
+import (
+    "os"
+    "fmt"
+    "sort"
+    "strings"
+    "encoding/json"
+    "text/tabwriter"
+
+    "github.com/deatil/lakego-doak/lakego/color"
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/command"
+)
+
+/**
+ * 路由列表
+ *
+ * > ./main route:list [--method=GET] [--name=admin.] [--path=/admin] [--json]
+ * > main.exe route:list [--method=GET] [--name=admin.] [--path=/admin] [--json]
+ * > go run main.go route:list [--method=GET] [--name=admin.] [--path=/admin] [--json]
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var RouteListCmd = &command.Command{
+    Use: "route:list",
+    Short: "查看已注册的路由列表。",
+    Example: "{execfile} route:list --method=GET --path=/admin",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+    },
+    RunE: func(cmd *command.Command, args []string) error {
+        return List(listMethod, listName, listPath, listJSON)
+    },
+}
+
+var (
+    // 请求方式
+    listMethod string
+
+    // 别名
+    listName string
+
+    // 路径
+    listPath string
+
+    // 输出 json
+    listJSON bool
+)
+
+func init() {
+    pf := RouteListCmd.Flags()
+    pf.StringVarP(&listMethod, "method", "m", "", "请求方式")
+    pf.StringVarP(&listName, "name", "n", "", "别名，包含即可")
+    pf.StringVarP(&listPath, "path", "p", "", "路径，包含即可")
+    pf.BoolVarP(&listJSON, "json", "j", false, "输出 json 格式")
+}
+
+/**
+ * 路由信息
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type RouteItem struct {
+    // 请求方式
+    Method string `json:"method"`
+
+    // 路径
+    Path string `json:"path"`
+
+    // 别名
+    Name string `json:"name"`
+
+    // 处理方法
+    Handler string `json:"handler"`
+
+    // 中间件
+    Middlewares []string `json:"middlewares"`
+}
+
+// 路由列表
+func List(method string, name string, path string, asJSON bool) error {
+    if router.DefaultRoute().Get() == nil {
+        return fmt.Errorf("路由没有初始化")
+    }
+
+    items := Routes(method, name, path)
+
+    if asJSON {
+        data, err := json.MarshalIndent(items, "", "  ")
+        if err != nil {
+            return err
+        }
+
+        fmt.Println(string(data))
+        return nil
+    }
+
+    if len(items) == 0 {
+        color.Greenln("没有匹配的路由")
+        return nil
+    }
+
+    w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+    fmt.Fprintln(w, "请求方式\t路径\t别名\t处理方法\t中间件")
+
+    for _, item := range items {
+        middlewares := strings.Join(item.Middlewares, " > ")
+
+        fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+            item.Method,
+            item.Path,
+            orDash(item.Name),
+            item.Handler,
+            orDash(middlewares),
+        )
+    }
+
+    w.Flush()
+
+    fmt.Print("\n")
+    color.Greenln(fmt.Sprintf("共 %d 条路由", len(items)))
+
+    return nil
+}
+
+// 匹配的路由，按路径及请求方式排序
+func Routes(method string, name string, path string) []RouteItem {
+    routeNames := router.DefaultName()
+
+    items := make([]RouteItem, 0)
+    for _, route := range router.DefaultRoute().GetRoutes() {
+        if method != "" && !strings.EqualFold(route.Method, method) {
+            continue
+        }
+
+        if path != "" && !strings.Contains(route.Path, path) {
+            continue
+        }
+
+        routeName := routeNames.GetNameByRoute(route.Method, route.Path)
+        if name != "" && !strings.Contains(routeName, name) {
+            continue
+        }
+
+        // 处理链最后一个为处理函数
+        middlewares := make([]string, 0)
+        if chain := router.DefaultRoute().GetHandlerChain(route.Method, route.Path); len(chain) > 0 {
+            middlewares = chain[:len(chain) - 1]
+        }
+
+        items = append(items, RouteItem{
+            Method:      route.Method,
+            Path:        route.Path,
+            Name:        routeName,
+            Handler:     route.Handler,
+            Middlewares: middlewares,
+        })
+    }
+
+    sort.SliceStable(items, func(i, j int) bool {
+        if items[i].Path != items[j].Path {
+            return items[i].Path < items[j].Path
+        }
+
+        return items[i].Method < items[j].Method
+    })
+
+    return items
+}
+
+// 空值显示
+func orDash(s string) string {
+    if s == "" {
+        return "-"
+    }
+
+    return s
+}
@@ -1,7 +1,15 @@
 package schedule
 
 import (
+    "os"
     "fmt"
+    "time"
+    "errors"
+    "context"
+    "strings"
+    "syscall"
+    "os/signal"
+    "text/tabwriter"
 
     "github.com/deatil/go-datebin/datebin"
 
@@ -32,13 +40,82 @@ var ScheduleCmd = &command.Command{
     },
 }
 
+/**
+ * 计划任务列表
+ *
+ * > ./main schedule:list
+ * > main.exe schedule:list
+ * > go run main.go schedule:list
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var ScheduleListCmd = &command.Command{
+    Use: "schedule:list",
+    Short: "查看计划任务列表。",
+    Example: "{execfile} schedule:list",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+    },
+    Run: func(cmd *command.Command, args []string) {
+
+    },
+}
+
+/**
+ * 执行当前需要执行的计划任务，执行完成后退出，用于系统 crontab 每分钟调用
+ *
+ * > ./main schedule:run
+ * > main.exe schedule:run
+ * > go run main.go schedule:run
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var ScheduleRunCmd = &command.Command{
+    Use: "schedule:run",
+    Short: "执行当前分钟需要执行的计划任务后退出。",
+    Example: "{execfile} schedule:run",
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+    },
+    Run: func(cmd *command.Command, args []string) {
+
+    },
+}
+
+/**
+ * 立即执行一个计划任务
+ *
+ * > ./main schedule:test <name>
+ * > main.exe schedule:test <name>
+ * > go run main.go schedule:test <name>
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+var ScheduleTestCmd = &command.Command{
+    Use: "schedule:test <name>",
+    Short: "立即执行一个计划任务，不检测执行条件。",
+    Example: "{execfile} schedule:test cache:clear",
+    Args: command.ExactArgs(1),
+    SilenceUsage: true,
+    PreRun: func(cmd *command.Command, args []string) {
+    },
+    Run: func(cmd *command.Command, args []string) {
+
+    },
+}
+
 // 构造函数
 func NewScheduleCmd(s *schedule.Schedule) *command.Command {
     ScheduleCmd.Run = func(cmd *command.Command, args []string) {
         nowDate := datebin.Now().ToDatetimeString()
 
+        ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+        defer stop()
+
         s.Start()
-        defer s.Stop()
 
         ids := s.CronIDs()
         cronCount := fmt.Sprintf("%d", len(ids))
@@ -53,8 +130,193 @@ func NewScheduleCmd(s *schedule.Schedule) *command.Command {
             Print("[" + nowDate + "] 计划任务共 " + cronCount + " 条已开始进行...")
         fmt.Print("\n")
 
-        select {}
+        // 检测错过执行的任务
+        go s.WatchMissed(ctx, time.Minute)
+
+        <-ctx.Done()
+
+        color.Yellowln("[" + datebin.Now().ToDatetimeString() + "] 正在停止计划任务，等待执行中的任务完成...")
+
+        <-s.Stop().Done()
+
+        color.Greenln("[" + datebin.Now().ToDatetimeString() + "] 计划任务已停止")
     }
 
     return ScheduleCmd
 }
+
+// 计划任务列表
+func NewScheduleListCmd(s *schedule.Schedule) *command.Command {
+    ScheduleListCmd.Run = func(cmd *command.Command, args []string) {
+        List(s)
+    }
+
+    return ScheduleListCmd
+}
+
+// 执行当前需要执行的计划任务
+func NewScheduleRunCmd(s *schedule.Schedule) *command.Command {
+    ScheduleRunCmd.Run = func(cmd *command.Command, args []string) {
+        RunDue(s)
+    }
+
+    return ScheduleRunCmd
+}
+
+// 立即执行一个计划任务
+func NewScheduleTestCmd(s *schedule.Schedule) *command.Command {
+    ScheduleTestCmd.RunE = func(cmd *command.Command, args []string) error {
+        return Test(s, args[0])
+    }
+    ScheduleTestCmd.Run = nil
+
+    return ScheduleTestCmd
+}
+
+// 计划任务列表
+func List(s *schedule.Schedule) {
+    entries := s.Entries()
+    if len(entries) == 0 {
+        color.Greenln("没有计划任务")
+        return
+    }
+
+    now := time.Now()
+
+    w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+    fmt.Fprintln(w, "名称\t计划时间\t下次执行\t上次执行\t状态\t选项")
+
+    for _, entry := range entries {
+        name := entry.Name
+        if name == "" {
+            name = "-"
+        }
+
+        spec := entry.Spec
+        if spec == "" {
+            spec = "-"
+        }
+
+        next := s.NextRun(entry, now)
+
+        var prev time.Time
+        // 计划任务已启动时使用运行中的时间
+        if cronEntry := s.CronEntry(entry.Name); cronEntry.Valid() && !cronEntry.Next.IsZero() {
+            next = cronEntry.Next
+            prev = cronEntry.Prev
+        }
+
+        // 有执行记录时使用最后一次执行记录
+        status := "正常"
+        if last := s.LastRun(entry.Name); last != nil {
+            prev = last.Start
+
+            if last.IsSkipped() {
+                status = "上次跳过"
+            } else if !last.IsSuccess() {
+                status = "上次失败"
+            }
+        }
+
+        if s.CronStopped(entry.Name) {
+            status = "已停止"
+        }
+
+        fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+            name,
+            spec,
+            formatTime(next),
+            formatTime(prev),
+            status,
+            entryOptions(entry),
+        )
+    }
+
+    w.Flush()
+}
+
+// 执行当前需要执行的计划任务
+func RunDue(s *schedule.Schedule) {
+    now := time.Now()
+
+    runs := s.RunDue(now)
+    s.Wait()
+
+    prefix := "[" + now.Format("2006-01-02 15:04:05") + "] "
+
+    if len(runs) == 0 {
+        color.Greenln(prefix + "没有需要执行的计划任务")
+        return
+    }
+
+    for _, run := range runs {
+        name := run.Entry.Name
+
+        switch {
+            case run.Err == nil:
+                color.Greenln(prefix + "已执行：" + name)
+            case errors.Is(run.Err, schedule.ErrFiltered):
+                color.Yellowln(prefix + "不满足执行条件：" + name)
+            case errors.Is(run.Err, schedule.ErrSkipped):
+                color.Yellowln(prefix + "跳过执行，任务锁被占用：" + name)
+            default:
+                color.Redln(prefix + "执行失败：" + name + "，" + run.Err.Error())
+        }
+    }
+}
+
+// 立即执行一个计划任务
+func Test(s *schedule.Schedule, name string) error {
+    entry := s.GetEntry(name)
+    if entry.Cmd == nil {
+        return fmt.Errorf("计划任务[%s]不存在", name)
+    }
+
+    start := time.Now()
+
+    color.Yellowln("正在执行：" + name)
+
+    if err := entry.Run(); err != nil {
+        if errors.Is(err, schedule.ErrSkipped) {
+            return errors.New("跳过执行：任务锁被占用")
+        }
+
+        return errors.New("执行失败：" + err.Error())
+    }
+
+    color.Greenln(fmt.Sprintf("执行完成，用时 %s", time.Since(start).Round(time.Millisecond)))
+
+    return nil
+}
+
+// 任务选项
+func entryOptions(entry *schedule.Entry) string {
+    options := make([]string, 0)
+
+    if entry.IsBackground() {
+        options = append(options, "后台")
+    }
+
+    if entry.IsWithoutOverlapping() {
+        options = append(options, "防重叠")
+    }
+
+    if entry.IsOnOneServer() {
+        options = append(options, "单服务器")
+    }
+
+    if len(options) == 0 {
+        return "-"
+    }
+
+    return strings.Join(options, ",")
+}
+
+// 格式化时间
+func formatTime(t time.Time) string {
+    if t.IsZero() {
+        return "-"
+    }
+
+    return t.Format("2006-01-02 15:04:05")
+}
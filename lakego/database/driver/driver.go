@@ -85,8 +85,8 @@ func (this *Driver) CreateOpenConnection(dia gorm.Dialector) {
     // 日志等级
     logLevel := cfg.Value("log-level").ToString()
 
-    // 日志
-    gormLogger := logger.New(log.New(os.Stdout, "\r\n", log.LstdFlags), logger.Config{
+    // 日志，请求上下文中有日志时使用上下文日志
+    gormLogger := NewContextLogger(log.New(os.Stdout, "\r\n", log.LstdFlags), logger.Config{
         // 默认 200 * time.Millisecond
         SlowThreshold:             cfg.Value("log-slow-threshold").ToDuration(),
         LogLevel:                  getLogLevel(logLevel),
@@ -0,0 +1,122 @@
+package driver
+
+import (
+    "time"
+    "errors"
+    "context"
+
+    "gorm.io/gorm"
+    gormLogger "gorm.io/gorm/logger"
+
+    "github.com/deatil/lakego-doak/lakego/logger"
+)
+
+/**
+ * 数据库日志
+ *
+ * 上下文中存在请求日志时使用请求日志记录，
+ * 否则使用默认的 gorm 日志
+ *
+ * db.WithContext(ctx).Find(&data)
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type ContextLogger struct {
+    // 默认日志
+    gormLogger.Interface
+
+    // 配置
+    config gormLogger.Config
+}
+
+// 构造函数
+func NewContextLogger(writer gormLogger.Writer, config gormLogger.Config) *ContextLogger {
+    return &ContextLogger{
+        Interface: gormLogger.New(writer, config),
+        config:    config,
+    }
+}
+
+// 设置日志等级
+func (this *ContextLogger) LogMode(level gormLogger.LogLevel) gormLogger.Interface {
+    config := this.config
+    config.LogLevel = level
+
+    return &ContextLogger{
+        Interface: this.Interface.LogMode(level),
+        config:    config,
+    }
+}
+
+// 信息
+func (this *ContextLogger) Info(ctx context.Context, msg string, data ...any) {
+    if log := logger.FromContext(ctx); log != nil {
+        if this.config.LogLevel >= gormLogger.Info {
+            log.Infof(msg, data...)
+        }
+
+        return
+    }
+
+    this.Interface.Info(ctx, msg, data...)
+}
+
+// 警告
+func (this *ContextLogger) Warn(ctx context.Context, msg string, data ...any) {
+    if log := logger.FromContext(ctx); log != nil {
+        if this.config.LogLevel >= gormLogger.Warn {
+            log.Warnf(msg, data...)
+        }
+
+        return
+    }
+
+    this.Interface.Warn(ctx, msg, data...)
+}
+
+// 错误
+func (this *ContextLogger) Error(ctx context.Context, msg string, data ...any) {
+    if log := logger.FromContext(ctx); log != nil {
+        if this.config.LogLevel >= gormLogger.Error {
+            log.Errorf(msg, data...)
+        }
+
+        return
+    }
+
+    this.Interface.Error(ctx, msg, data...)
+}
+
+// sql 记录
+func (this *ContextLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+    log := logger.FromContext(ctx)
+    if log == nil {
+        this.Interface.Trace(ctx, begin, fc, err)
+        return
+    }
+
+    // 根据结果选择日志等级，格式沿用 gorm 日志
+    printf := log.Infof
+
+    elapsed := time.Since(begin)
+    switch {
+        case err != nil && (!errors.Is(err, gorm.ErrRecordNotFound) || !this.config.IgnoreRecordNotFoundError):
+            printf = log.Errorf
+
+        case this.config.SlowThreshold != 0 && elapsed > this.config.SlowThreshold:
+            printf = log.Warnf
+    }
+
+    config := this.config
+    config.Colorful = false
+
+    gormLogger.New(printer(printf), config).Trace(ctx, begin, fc, err)
+}
+
+// 日志输出
+type printer func(string, ...any)
+
+func (this printer) Printf(format string, args ...any) {
+    this(format, args...)
+}
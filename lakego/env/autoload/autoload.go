@@ -3,6 +3,7 @@ package autoload
 import "github.com/deatil/lakego-doak/lakego/env"
 
 // 自动加载
+// 导入顺序 .env < .env.{LAKEGO_ENV} < .env.local
 func init() {
-    env.Load()
+    env.NewLoader().Load()
 }
@@ -0,0 +1,398 @@
+package env
+
+import (
+    "os"
+    "fmt"
+    "sort"
+    "sync"
+    "errors"
+    "strings"
+    "path/filepath"
+)
+
+// 运行模式环境变量
+const ModeKey = "LAKEGO_ENV"
+
+// 解析时原有的 \$ 转义的占位符
+const escapedDollar = "\x00"
+
+var (
+    // 锁
+    requiredMu sync.RWMutex
+
+    // 必须的环境变量
+    requiredKeys = make([]string, 0)
+)
+
+// 声明必须存在的环境变量
+func Require(keys ...string) {
+    requiredMu.Lock()
+    defer requiredMu.Unlock()
+
+    for _, key := range keys {
+        if key == "" || inSlice(key, requiredKeys) {
+            continue
+        }
+
+        requiredKeys = append(requiredKeys, key)
+    }
+}
+
+// 已声明的必须环境变量
+func RequiredKeys() []string {
+    requiredMu.RLock()
+    defer requiredMu.RUnlock()
+
+    keys := make([]string, len(requiredKeys))
+    copy(keys, requiredKeys)
+
+    return keys
+}
+
+/**
+ * 缺失环境变量错误
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type MissingError struct {
+    // 缺失的变量
+    Keys []string
+}
+
+// 错误信息
+func (this *MissingError) Error() string {
+    return "env: missing required variables: " + strings.Join(this.Keys, ", ")
+}
+
+/**
+ * env 文件导入
+ *
+ * 导入顺序 .env < .env.{mode} < .env.local，系统已有的环境变量优先
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Loader struct {
+    // 文件目录
+    path string
+
+    // 运行模式
+    mode string
+
+    // 必须存在的变量
+    required []string
+}
+
+// 构造函数
+func NewLoader() *Loader {
+    return &Loader{
+        mode:     os.Getenv(ModeKey),
+        required: make([]string, 0),
+    }
+}
+
+// 设置文件目录
+func (this *Loader) WithPath(path string) *Loader {
+    this.path = path
+
+    return this
+}
+
+// 设置运行模式
+func (this *Loader) WithMode(mode string) *Loader {
+    this.mode = mode
+
+    return this
+}
+
+// 获取运行模式
+func (this *Loader) GetMode() string {
+    return this.mode
+}
+
+// 设置必须存在的变量
+func (this *Loader) WithRequired(keys ...string) *Loader {
+    for _, key := range keys {
+        if key != "" && !inSlice(key, this.required) {
+            this.required = append(this.required, key)
+        }
+    }
+
+    return this
+}
+
+// 文件列表，优先级从低到高
+func (this *Loader) Files() []string {
+    names := []string{".env"}
+
+    if this.mode != "" {
+        names = append(names, ".env." + this.mode)
+    }
+
+    names = append(names, ".env.local")
+
+    files := make([]string, 0, len(names))
+    for _, name := range names {
+        files = append(files, filepath.Join(this.path, name))
+    }
+
+    return files
+}
+
+// 读取全部文件并替换变量，不写入系统环境变量
+func (this *Loader) Read() (map[string]string, error) {
+    values := make(map[string]string)
+
+    for _, file := range this.Files() {
+        data, err := readFile(file)
+        if err != nil {
+            if errors.Is(err, os.ErrNotExist) {
+                continue
+            }
+
+            return nil, err
+        }
+
+        for k, v := range data {
+            values[k] = v
+        }
+    }
+
+    return resolveValues(values), nil
+}
+
+// 导入
+func (this *Loader) Load() error {
+    values, err := this.Read()
+    if err != nil {
+        return err
+    }
+
+    for key, value := range values {
+        // 系统已有的环境变量优先
+        if _, ok := os.LookupEnv(key); ok {
+            continue
+        }
+
+        if err := os.Setenv(key, value); err != nil {
+            return err
+        }
+    }
+
+    return this.Check()
+}
+
+// 检测必须存在的变量
+func (this *Loader) Check() error {
+    required := append(RequiredKeys(), this.required...)
+
+    missing := make([]string, 0)
+    for _, key := range required {
+        if value, ok := os.LookupEnv(key); !ok || value == "" {
+            if !inSlice(key, missing) {
+                missing = append(missing, key)
+            }
+        }
+    }
+
+    if len(missing) > 0 {
+        sort.Strings(missing)
+
+        return &MissingError{
+            Keys: missing,
+        }
+    }
+
+    return nil
+}
+
+// 根据运行模式导入 env 文件
+func LoadWithMode(mode string) error {
+    return NewLoader().WithMode(mode).Load()
+}
+
+// ==========
+
+// 替换字符中的 ${VAR}、${VAR:-default}、${VAR-default} 及 $VAR
+// \$ 为转义的 $ 字符
+func Interpolate(s string, lookup func(string) (string, bool)) string {
+    var b strings.Builder
+
+    for i := 0; i < len(s); i++ {
+        c := s[i]
+
+        if c == '\\' && i+1 < len(s) && s[i+1] == '$' {
+            b.WriteByte('$')
+            i++
+            continue
+        }
+
+        if c != '$' || i+1 >= len(s) {
+            b.WriteByte(c)
+            continue
+        }
+
+        // ${...} 格式
+        if s[i+1] == '{' {
+            end := matchBrace(s, i+1)
+            if end < 0 {
+                b.WriteString(s[i:])
+                break
+            }
+
+            b.WriteString(expandExpr(s[i+2:end], lookup))
+            i = end
+            continue
+        }
+
+        // $VAR 格式
+        j := i + 1
+        for j < len(s) && isNameChar(s[j], j == i+1) {
+            j++
+        }
+
+        if j == i+1 {
+            b.WriteByte(c)
+            continue
+        }
+
+        value, _ := lookup(s[i+1:j])
+        b.WriteString(value)
+        i = j - 1
+    }
+
+    return b.String()
+}
+
+// 解析 ${} 内的表达式
+func expandExpr(expr string, lookup func(string) (string, bool)) string {
+    name := expr
+    def := ""
+    hasDef := false
+    emptyAsUnset := false
+
+    if idx := strings.Index(expr, ":-"); idx >= 0 {
+        name, def = expr[:idx], expr[idx+2:]
+        hasDef, emptyAsUnset = true, true
+    } else if idx := strings.Index(expr, "-"); idx >= 0 {
+        name, def = expr[:idx], expr[idx+1:]
+        hasDef = true
+    }
+
+    value, ok := lookup(name)
+    if hasDef && (!ok || (emptyAsUnset && value == "")) {
+        return Interpolate(def, lookup)
+    }
+
+    return value
+}
+
+// 查找对应的结束括号
+func matchBrace(s string, start int) int {
+    depth := 0
+    for i := start; i < len(s); i++ {
+        switch s[i] {
+            case '{':
+                depth++
+            case '}':
+                depth--
+                if depth == 0 {
+                    return i
+                }
+        }
+    }
+
+    return -1
+}
+
+// 变量名字符
+func isNameChar(c byte, first bool) bool {
+    if c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') {
+        return true
+    }
+
+    return !first && c >= '0' && c <= '9'
+}
+
+// 替换全部值中的变量，系统环境变量优先
+func resolveValues(values map[string]string) map[string]string {
+    resolved := make(map[string]string, len(values))
+    resolving := make(map[string]bool)
+
+    var lookup func(string) (string, bool)
+    lookup = func(key string) (string, bool) {
+        if value, ok := os.LookupEnv(key); ok {
+            return value, true
+        }
+
+        if value, ok := resolved[key]; ok {
+            return value, true
+        }
+
+        raw, ok := values[key]
+        if !ok || resolving[key] {
+            return "", false
+        }
+
+        resolving[key] = true
+        value := Interpolate(raw, lookup)
+        delete(resolving, key)
+
+        resolved[key] = value
+
+        return value, true
+    }
+
+    // 系统已存在的变量也返回文件内的值
+    data := make(map[string]string, len(values))
+    for key, raw := range values {
+        lookup(key)
+
+        if value, ok := resolved[key]; ok {
+            data[key] = value
+        } else {
+            data[key] = Interpolate(raw, lookup)
+        }
+    }
+
+    return data
+}
+
+// 读取 env 文件，使用 godotenv 解析
+//
+// 解析前将 $ 转义，godotenv 不做替换，单引号内的值保留转义，
+// 替换时 \$ 作为 $ 字符输出，所以单引号内的值不做替换
+func readFile(file string) (map[string]string, error) {
+    data, err := os.ReadFile(file)
+    if err != nil {
+        return nil, err
+    }
+
+    content := strings.NewReplacer(
+        `\$`, escapedDollar,
+        "$", `\$`,
+    ).Replace(string(data))
+
+    values, err := Parse(strings.NewReader(content))
+    if err != nil {
+        return nil, fmt.Errorf("env: parse %s failed: %w", file, err)
+    }
+
+    for key, value := range values {
+        values[key] = strings.ReplaceAll(value, escapedDollar, `\$`)
+    }
+
+    return values, nil
+}
+
+// 是否在切片内
+func inSlice(key string, data []string) bool {
+    for _, v := range data {
+        if v == key {
+            return true
+        }
+    }
+
+    return false
+}
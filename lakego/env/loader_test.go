@@ -0,0 +1,117 @@
+package env
+
+import (
+    "os"
+    "errors"
+    "testing"
+    "reflect"
+    "path/filepath"
+)
+
+func assertT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func Test_Interpolate(t *testing.T) {
+    assert := assertT(t)
+
+    data := map[string]string{
+        "HOST":  "127.0.0.1",
+        "PORT":  "3306",
+        "EMPTY": "",
+    }
+
+    lookup := func(key string) (string, bool) {
+        value, ok := data[key]
+        return value, ok
+    }
+
+    assert(Interpolate("${HOST}:${PORT}", lookup), "127.0.0.1:3306", "Interpolate braces")
+    assert(Interpolate("$HOST:$PORT", lookup), "127.0.0.1:3306", "Interpolate plain")
+    assert(Interpolate("${USER:-root}", lookup), "root", "Interpolate default")
+    assert(Interpolate("${EMPTY:-def}", lookup), "def", "Interpolate empty default")
+    assert(Interpolate("${EMPTY-def}", lookup), "", "Interpolate unset default")
+    assert(Interpolate("${NAME:-${HOST}}", lookup), "127.0.0.1", "Interpolate nested default")
+    assert(Interpolate(`\${HOST}`, lookup), "${HOST}", "Interpolate escape")
+    assert(Interpolate("price $", lookup), "price $", "Interpolate tail")
+}
+
+func Test_Loader(t *testing.T) {
+    assert := assertT(t)
+
+    dir := t.TempDir()
+
+    files := map[string]string{
+        ".env":       "LK_APP=lakego\nLK_DB_HOST=localhost\nLK_DB_URL=\"mysql://${LK_DB_HOST}:${LK_DB_PORT:-3306}\"\nLK_RAW='${LK_APP}'\n",
+        ".env.prod":  "LK_DB_HOST=db.prod # host\n",
+        ".env.local": "export LK_DB_PORT=3307\n",
+    }
+    for name, content := range files {
+        if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+            t.Fatal(err)
+        }
+    }
+
+    loader := NewLoader().WithPath(dir).WithMode("prod")
+
+    values, err := loader.Read()
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    assert(values["LK_APP"], "lakego", "Loader value")
+    assert(values["LK_DB_HOST"], "db.prod", "Loader mode override")
+    assert(values["LK_DB_URL"], "mysql://db.prod:3307", "Loader interpolate")
+    assert(values["LK_RAW"], "${LK_APP}", "Loader literal")
+
+    t.Setenv("LK_APP", "from-os")
+
+    err = loader.WithRequired("LK_APP", "LK_MISSING_B", "LK_MISSING_A").Load()
+
+    var missingErr *MissingError
+    assert(errors.As(err, &missingErr), true, "Loader missing error")
+    assert(missingErr.Keys, []string{"LK_MISSING_A", "LK_MISSING_B"}, "Loader missing keys")
+    assert(os.Getenv("LK_APP"), "from-os", "Loader keep os env")
+    assert(os.Getenv("LK_DB_HOST"), "db.prod", "Loader set env")
+
+    Remove("LK_DB_HOST", "LK_DB_PORT", "LK_DB_URL", "LK_RAW")
+}
+
+func Test_LoaderParse(t *testing.T) {
+    assert := assertT(t)
+
+    dir := t.TempDir()
+
+    content := "LK_URL=http://127.0.0.1:8080/path\n" +
+        "LK_QUOTED=\"a\" # \"b\"\n" +
+        "LK_HASH=\"a # b\"\n" +
+        "LK_PRICE=\"\\$10 ${LK_UNSET:-free}\"\n" +
+        "LK_SINGLE='$LK_URL \\$'\n" +
+        "LK_NEWLINE=\"a\\nb\"\n"
+    if err := os.WriteFile(filepath.Join(dir, ".env"), []byte(content), 0644); err != nil {
+        t.Fatal(err)
+    }
+
+    values, err := NewLoader().WithPath(dir).Read()
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    assert(values["LK_URL"], "http://127.0.0.1:8080/path", "Loader url value")
+    assert(values["LK_QUOTED"], "a", "Loader quoted with comment")
+    assert(values["LK_HASH"], "a # b", "Loader quoted hash")
+    assert(values["LK_PRICE"], "$10 free", "Loader escaped dollar")
+    assert(values["LK_SINGLE"], "$LK_URL $", "Loader single quoted")
+    assert(values["LK_NEWLINE"], "a\nb", "Loader newline")
+
+    if err := os.WriteFile(filepath.Join(dir, ".env.local"), []byte("not a line\n"), 0644); err != nil {
+        t.Fatal(err)
+    }
+
+    _, err = NewLoader().WithPath(dir).Read()
+    assert(err != nil, true, "Loader parse error")
+}
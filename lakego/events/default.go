@@ -0,0 +1,49 @@
+package events
+
+import (
+    "context"
+    "reflect"
+)
+
+// 默认
+var defaultDispatcher = New()
+
+// 默认调度器
+func Default() *Dispatcher {
+    return defaultDispatcher
+}
+
+// 监听事件
+// events.Listen(func(ctx context.Context, e UserLoggedIn) error { return nil }, events.Priority(10))
+func Listen[T any](handler func(ctx context.Context, event T) error, opts ...Option) *Subscription {
+    return ListenOn(defaultDispatcher, handler, opts...)
+}
+
+// 在指定调度器监听事件，T 为接口时监听实现该接口的全部事件
+func ListenOn[T any](d *Dispatcher, handler func(ctx context.Context, event T) error, opts ...Option) *Subscription {
+    typ := reflect.TypeOf((*T)(nil)).Elem()
+
+    return d.ListenType(typ, func(ctx context.Context, e any) error {
+        return handler(ctx, e.(T))
+    }, opts...)
+}
+
+// 通配监听
+func ListenWildcard(pattern string, handler Listener, opts ...Option) *Subscription {
+    return defaultDispatcher.ListenWildcard(pattern, handler, opts...)
+}
+
+// 触发事件
+func Dispatch(ctx context.Context, event any) error {
+    return defaultDispatcher.Dispatch(ctx, event)
+}
+
+// 是否有监听器
+func HasListeners(event any) bool {
+    return defaultDispatcher.HasListeners(event)
+}
+
+// 关闭异步投递
+func Shutdown(ctx context.Context) error {
+    return defaultDispatcher.Shutdown(ctx)
+}
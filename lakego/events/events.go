@@ -0,0 +1,343 @@
+package events
+
+import (
+    "sort"
+    "sync"
+    "time"
+    "context"
+    "reflect"
+
+    "github.com/deatil/lakego-doak/lakego/gmq"
+)
+
+// 异步投递的主题
+const asyncTopic = "events"
+
+// 异步投递的内容
+type asyncPayload struct {
+    // 上下文
+    ctx context.Context
+
+    // 监听器
+    listener *listener
+
+    // 事件
+    event any
+}
+
+/**
+ * 事件调度器
+ *
+ * d := events.New()
+ * events.ListenOn(d, func(ctx context.Context, e UserLoggedIn) error {
+ *     return nil
+ * }, events.Priority(10))
+ * d.Dispatch(ctx, UserLoggedIn{ID: 1})
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Dispatcher struct {
+    // 锁
+    mu sync.RWMutex
+
+    // 按事件类型的监听器
+    listeners map[reflect.Type][]*listener
+
+    // 通配及接口类型的监听器
+    wildcards []*listener
+
+    // 监听器 ID
+    nextID uint64
+
+    // 异步投递
+    mq *gmq.GMQ
+
+    // 异步投递启动
+    mqOnce sync.Once
+
+    // 异步投递启动错误
+    mqErr error
+}
+
+// 构造函数
+func New() *Dispatcher {
+    return &Dispatcher{
+        listeners: make(map[reflect.Type][]*listener),
+    }
+}
+
+// 设置异步投递使用的 gmq，需要在异步监听器触发前设置
+func (this *Dispatcher) WithGMQ(mq *gmq.GMQ) *Dispatcher {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.mq = mq
+
+    return this
+}
+
+// 监听事件，event 为事件的值，按其类型匹配
+// d.Listen(UserLoggedIn{}, func(ctx context.Context, event any) error { return nil })
+func (this *Dispatcher) Listen(event any, handler Listener, opts ...Option) *Subscription {
+    return this.ListenType(reflect.TypeOf(event), handler, opts...)
+}
+
+// 按类型监听，接口类型时匹配实现该接口的全部事件
+func (this *Dispatcher) ListenType(typ reflect.Type, handler Listener, opts ...Option) *Subscription {
+    if typ == nil {
+        panic("events: listen to nil event")
+    }
+
+    if typ.Kind() == reflect.Interface {
+        return this.add(&listener{
+            iface:   typ,
+            handler: handler,
+        }, opts)
+    }
+
+    return this.add(&listener{
+        typ:     typ,
+        handler: handler,
+    }, opts)
+}
+
+// 通配监听，按事件名称匹配，支持 user.* 及 #，# 匹配全部事件
+func (this *Dispatcher) ListenWildcard(pattern string, handler Listener, opts ...Option) *Subscription {
+    return this.add(&listener{
+        pattern: pattern,
+        handler: handler,
+    }, opts)
+}
+
+// 添加监听器
+func (this *Dispatcher) add(l *listener, opts []Option) *Subscription {
+    for _, opt := range opts {
+        opt(l)
+    }
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.nextID++
+    l.id = this.nextID
+
+    if l.typ == nil {
+        this.wildcards = append(this.wildcards, l)
+    } else {
+        this.listeners[l.typ] = append(this.listeners[l.typ], l)
+    }
+
+    return &Subscription{
+        listener:   l,
+        dispatcher: this,
+    }
+}
+
+// 取消监听
+func (this *Dispatcher) Unsubscribe(sub *Subscription) bool {
+    if sub == nil {
+        return false
+    }
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    l := sub.listener
+
+    var list []*listener
+    if l.typ == nil {
+        list = this.wildcards
+    } else {
+        list = this.listeners[l.typ]
+    }
+
+    for i, item := range list {
+        if item.id != l.id {
+            continue
+        }
+
+        newList := make([]*listener, 0, len(list) - 1)
+        newList = append(newList, list[:i]...)
+        newList = append(newList, list[i+1:]...)
+
+        if l.typ == nil {
+            this.wildcards = newList
+        } else if len(newList) == 0 {
+            delete(this.listeners, l.typ)
+        } else {
+            this.listeners[l.typ] = newList
+        }
+
+        return true
+    }
+
+    return false
+}
+
+// 是否有监听器
+func (this *Dispatcher) HasListeners(event any) bool {
+    return len(this.getListeners(event)) > 0
+}
+
+// 触发事件，按优先级执行监听器
+// 同步监听器返回 ErrStopPropagation 时停止后续监听器，返回其他错误时停止并返回该错误
+func (this *Dispatcher) Dispatch(ctx context.Context, event any) error {
+    if event == nil {
+        return nil
+    }
+
+    for _, l := range this.getListeners(event) {
+        if l.async {
+            if err := this.publish(ctx, l, event); err != nil {
+                return err
+            }
+
+            continue
+        }
+
+        err := l.call(ctx, event)
+        if err == ErrStopPropagation {
+            return nil
+        }
+
+        if err != nil {
+            return err
+        }
+    }
+
+    return nil
+}
+
+// 事件的监听器，按优先级及注册顺序排列
+func (this *Dispatcher) getListeners(event any) []*listener {
+    if event == nil {
+        return nil
+    }
+
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    typ := reflect.TypeOf(event)
+
+    list := make([]*listener, 0, len(this.listeners[typ]))
+    list = append(list, this.listeners[typ]...)
+
+    if len(this.wildcards) > 0 {
+        name := EventName(event)
+
+        for _, l := range this.wildcards {
+            if l.iface != nil {
+                if typ.Implements(l.iface) {
+                    list = append(list, l)
+                }
+
+                continue
+            }
+
+            if gmq.MatchTopic(l.pattern, name) {
+                list = append(list, l)
+            }
+        }
+    }
+
+    sort.SliceStable(list, func(i, j int) bool {
+        if list[i].priority != list[j].priority {
+            return list[i].priority > list[j].priority
+        }
+
+        return list[i].id < list[j].id
+    })
+
+    return list
+}
+
+// 异步投递，异步监听器只保留 ctx 的值，不继承截止时间及取消
+func (this *Dispatcher) publish(ctx context.Context, l *listener, event any) error {
+    mq, err := this.getGMQ()
+    if err != nil {
+        return err
+    }
+
+    if ctx == nil {
+        ctx = context.Background()
+    }
+
+    return mq.Publish(asyncTopic, asyncPayload{
+        ctx:      detachedContext{ctx},
+        listener: l,
+        event:    event,
+    })
+}
+
+// 异步投递使用的 gmq，首次使用时启动，启动失败时每次都返回启动错误
+func (this *Dispatcher) getGMQ() (*gmq.GMQ, error) {
+    this.mqOnce.Do(func() {
+        this.mu.Lock()
+        if this.mq == nil {
+            this.mq = gmq.New()
+        }
+        mq := this.mq
+        this.mu.Unlock()
+
+        mq.Subscribe(asyncTopic, func(value any) error {
+            payload := value.(asyncPayload)
+
+            err := payload.listener.call(payload.ctx, payload.event)
+            if err == ErrStopPropagation {
+                return nil
+            }
+
+            return err
+        })
+
+        err := mq.Start()
+
+        this.mu.Lock()
+        this.mqErr = err
+        this.mu.Unlock()
+    })
+
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    if this.mqErr != nil {
+        return nil, this.mqErr
+    }
+
+    return this.mq, nil
+}
+
+// 关闭异步投递，等待已投递的监听器执行完成
+func (this *Dispatcher) Shutdown(ctx context.Context) error {
+    this.mu.RLock()
+    mq := this.mq
+    this.mu.RUnlock()
+
+    if mq == nil {
+        return nil
+    }
+
+    return mq.Shutdown(ctx)
+}
+
+// 异步监听器的上下文，只保留值，不继承截止时间及取消
+type detachedContext struct {
+    parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+    return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+    return nil
+}
+
+func (detachedContext) Err() error {
+    return nil
+}
+
+func (this detachedContext) Value(key any) any {
+    return this.parent.Value(key)
+}
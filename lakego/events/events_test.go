@@ -0,0 +1,74 @@
+package events
+
+import (
+    "time"
+    "context"
+    "testing"
+    "reflect"
+
+    "github.com/deatil/lakego-doak/lakego/gmq"
+)
+
+func assertT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+type ctxKey struct{}
+
+type userLoggedIn struct {
+    ID int
+}
+
+func Test_AsyncContext(t *testing.T) {
+    assert := assertT(t)
+
+    d := New()
+
+    type result struct {
+        value any
+        err   error
+    }
+
+    results := make(chan result, 1)
+    ListenOn(d, func(ctx context.Context, e userLoggedIn) error {
+        // 等待调用方的 ctx 取消
+        time.Sleep(20 * time.Millisecond)
+
+        results <- result{ctx.Value(ctxKey{}), ctx.Err()}
+        return nil
+    }, Async())
+
+    ctx, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "request-1"))
+    assert(d.Dispatch(ctx, userLoggedIn{ID: 1}), nil, "Dispatch")
+    cancel()
+
+    select {
+        case res := <-results:
+            assert(res.value, "request-1", "Async ctx value")
+            assert(res.err, nil, "Async ctx not canceled")
+        case <-time.After(time.Second):
+            t.Fatal("async listener not called")
+    }
+
+    d.Shutdown(context.Background())
+}
+
+func Test_StartError(t *testing.T) {
+    assert := assertT(t)
+
+    mq := gmq.New()
+    mq.Shutdown(context.Background())
+
+    d := New().WithGMQ(mq)
+    ListenOn(d, func(ctx context.Context, e userLoggedIn) error {
+        return nil
+    }, Async())
+
+    // 启动失败后每次触发都返回启动错误
+    assert(d.Dispatch(context.Background(), userLoggedIn{ID: 1}), gmq.ErrClosed, "Dispatch first")
+    assert(d.Dispatch(context.Background(), userLoggedIn{ID: 2}), gmq.ErrClosed, "Dispatch second")
+}
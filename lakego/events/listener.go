@@ -0,0 +1,105 @@
+package events
+
+import (
+    "fmt"
+    "errors"
+    "context"
+    "reflect"
+    "runtime/debug"
+)
+
+// 监听器返回该错误时停止后续监听器
+var ErrStopPropagation = errors.New("events: stop propagation")
+
+// 监听器
+type Listener func(ctx context.Context, event any) error
+
+// 自定义事件名称，未实现时使用类型名称
+type NamedEvent interface {
+    EventName() string
+}
+
+// 事件名称
+func EventName(event any) string {
+    if named, ok := event.(NamedEvent); ok {
+        return named.EventName()
+    }
+
+    return reflect.TypeOf(event).String()
+}
+
+// 监听设置
+type Option func(*listener)
+
+// 优先级，越大越先执行，相同时按注册顺序执行
+func Priority(priority int) Option {
+    return func(l *listener) {
+        l.priority = priority
+    }
+}
+
+// 异步执行，通过 gmq 投递，不影响事件传播
+func Async() Option {
+    return func(l *listener) {
+        l.async = true
+    }
+}
+
+// 监听器
+type listener struct {
+    // ID
+    id uint64
+
+    // 优先级
+    priority int
+
+    // 是否异步
+    async bool
+
+    // 事件类型，通配监听为 nil
+    typ reflect.Type
+
+    // 通配事件名称
+    pattern string
+
+    // 接口类型，匹配实现该接口的事件
+    iface reflect.Type
+
+    // 处理
+    handler Listener
+}
+
+// 运行，捕获 panic
+func (this *listener) call(ctx context.Context, event any) (err error) {
+    defer func() {
+        if r := recover(); r != nil {
+            err = fmt.Errorf("events: listener panic: %v\n%s", r, debug.Stack())
+        }
+    }()
+
+    return this.handler(ctx, event)
+}
+
+/**
+ * 监听注册
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Subscription struct {
+    // 监听器
+    listener *listener
+
+    // 所属调度器
+    dispatcher *Dispatcher
+}
+
+// ID
+func (this *Subscription) ID() uint64 {
+    return this.listener.id
+}
+
+// 取消监听
+func (this *Subscription) Unsubscribe() bool {
+    return this.dispatcher.Unsubscribe(this)
+}
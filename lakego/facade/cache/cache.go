@@ -1,6 +1,7 @@
 package cache
 
 import (
+    "sync"
     "strings"
 
     "github.com/deatil/lakego-doak/lakego/array"
@@ -10,6 +11,7 @@ import (
     "github.com/deatil/lakego-doak/lakego/cache"
     "github.com/deatil/lakego-doak/lakego/cache/interfaces"
     redisDriver "github.com/deatil/lakego-doak/lakego/cache/driver/redis"
+    memoryDriver "github.com/deatil/lakego-doak/lakego/cache/driver/memory"
 )
 
 /**
@@ -26,6 +28,14 @@ import (
 // 默认
 var Default *cache.Cache
 
+var (
+    // 内存驱动存储
+    memoryStore *memoryDriver.Memory
+
+    // 内存驱动存储创建
+    memoryOnce sync.Once
+)
+
 // 初始化
 func init() {
     // 注册默认
@@ -112,5 +122,17 @@ func registerDriver() {
 
             return driver
         })
+
+    // 注册内存驱动
+    register.
+        NewManagerWithPrefix("cache").
+        Register("memory", func(conf map[string]any) any {
+            // 内存驱动共用同一个存储
+            memoryOnce.Do(func() {
+                memoryStore = memoryDriver.New()
+            })
+
+            return memoryStore
+        })
 }
 
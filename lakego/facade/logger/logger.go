@@ -2,18 +2,31 @@ package logger
 
 import (
     "log"
+    "context"
+    "sync"
     "strings"
 
+    "github.com/sirupsen/logrus"
+
     "github.com/deatil/lakego-doak/lakego/register"
     "github.com/deatil/lakego-doak/lakego/facade/config"
     "github.com/deatil/lakego-doak/lakego/logger"
     "github.com/deatil/lakego-doak/lakego/logger/interfaces"
+    stackDriver "github.com/deatil/lakego-doak/lakego/logger/driver/stack"
     logrusDriver "github.com/deatil/lakego-doak/lakego/logger/driver/logrus"
 )
 
 // 默认
 var Default *logger.Logger
 
+var (
+    // 读写锁
+    channelMu sync.RWMutex
+
+    // 已使用的通道
+    channels = make(map[string]*logger.Logger)
+)
+
 // 初始化
 func init() {
     // 注册默认
@@ -67,16 +80,94 @@ func NewLogger(driverName string, once ...bool) *logger.Logger {
     return logger.New(driver.(interfaces.Driver))
 }
 
+// 单个通道日志
+// logger.Channel("stderr").Error("logger test")
+func Channel(name string) *logger.Logger {
+    name = strings.ToLower(name)
+
+    channelMu.RLock()
+    log, ok := channels[name]
+    channelMu.RUnlock()
+
+    if ok {
+        return log
+    }
+
+    log = NewLogger(name)
+
+    channelMu.Lock()
+    if used, ok := channels[name]; ok {
+        log = used
+    } else {
+        channels[name] = log
+    }
+    channelMu.Unlock()
+
+    return log
+}
+
+// 请求上下文日志，不存在时使用默认日志
+// logger.FromContext(ctx).Info("logger test")
+func FromContext(ctx context.Context) interfaces.Driver {
+    if driver := logger.FromContext(ctx); driver != nil {
+        return driver
+    }
+
+    return Default.GetDriver()
+}
+
+// 通道驱动类型
+func GetChannelType(name string) string {
+    drivers := config.New("logger").GetStringMap("drivers")
+
+    driverConf, ok := drivers[strings.ToLower(name)].(map[string]any)
+    if !ok {
+        return ""
+    }
+
+    driverType, _ := driverConf["type"].(string)
+
+    return driverType
+}
+
 // 自定义数据
 // import "github.com/deatil/lakego-doak/lakego/facade/logger"
-// logger.LogrusWithField(logger.New(), "system", "lakego").Info("logger test")
-func LogrusWithField(log *logger.Logger, key string, value any) *logrusDriver.Entry {
-    return log.WithField(key, value).(*logrusDriver.Entry)
+// logger.WithField("system", "lakego").Info("logger test")
+func WithField(key string, value any) logger.Entry {
+    return Default.WithField(key, value)
 }
 
 // 批量自定义数据
+func WithFields(fields map[string]any) logger.Entry {
+    return Default.WithFields(fields)
+}
+
+// 错误信息
+// logger.WithError(err).WithField("system", "lakego").Error("logger test")
+func WithError(err error) logger.Entry {
+    return Default.WithError(err)
+}
+
+// 上下文
+func WithContext(ctx context.Context) logger.Entry {
+    return Default.WithContext(ctx)
+}
+
+// logrus 自定义数据
+// Deprecated: 使用 WithField 替代，返回的 logger.Entry 可链式调用
+func LogrusWithField(log *logger.Logger, key string, value any) *logrusDriver.Entry {
+    return LogrusWithFields(log, map[string]any{key: value})
+}
+
+// logrus 批量自定义数据
+// 驱动不是 logrus 时使用 logrus 默认日志，输出到标准错误
+// Deprecated: 使用 WithFields 替代
 func LogrusWithFields(log *logger.Logger, fields map[string]any) *logrusDriver.Entry {
-    return log.WithFields(fields).(*logrusDriver.Entry)
+    if entry, ok := log.WithFields(fields).(*logrusDriver.FieldsEntry); ok {
+        return entry.GetEntry()
+    }
+
+    return logrus.WithFields(logrus.Fields(fields))
 }
 
 // 默认驱动
@@ -98,5 +189,66 @@ func registerDriver() {
 
                 return driver
             },
+
+            // 命令行彩色日志
+            "console": func(conf map[string]any) any {
+                driver := logrusDriver.New()
+
+                driver.WithConfig(mergeConfig(conf, map[string]any{
+                    "formatter": "console",
+                    "output":    "stdout",
+                }))
+
+                return driver
+            },
+
+            // 标准输出单行 json 日志
+            "stdout-json": func(conf map[string]any) any {
+                driver := logrusDriver.New()
+
+                driver.WithConfig(mergeConfig(conf, map[string]any{
+                    "formatter": "json-line",
+                    "output":    "stdout",
+                }))
+
+                return driver
+            },
+
+            // 多通道日志
+            "stack": func(conf map[string]any) any {
+                driver := stackDriver.New()
+
+                for _, channel := range stackDriver.ParseChannels(conf) {
+                    // 不支持嵌套
+                    if GetChannelType(channel.Name) == "stack" {
+                        log.Print("日志通道[" + channel.Name + "]不能为 stack 类型")
+                        continue
+                    }
+
+                    driver.WithChannel(
+                        channel.Name,
+                        channel.Level,
+                        Channel(channel.Name).GetDriver(),
+                    )
+                }
+
+                return driver
+            },
         })
 }
+
+// 合并配置，未设置的使用默认值
+func mergeConfig(conf map[string]any, defaults map[string]any) map[string]any {
+    newConf := make(map[string]any, len(conf) + len(defaults))
+    for k, v := range defaults {
+        newConf[k] = v
+    }
+
+    for k, v := range conf {
+        if v != nil && v != "" {
+            newConf[k] = v
+        }
+    }
+
+    return newConf
+}
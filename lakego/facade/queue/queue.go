@@ -0,0 +1,235 @@
+package queue
+
+import (
+    "sync"
+    "time"
+    "context"
+    "strings"
+
+    "github.com/deatil/go-goch/goch"
+
+    "github.com/deatil/lakego-doak/lakego/array"
+    "github.com/deatil/lakego-doak/lakego/register"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+    "github.com/deatil/lakego-doak/lakego/facade/redis"
+    "github.com/deatil/lakego-doak/lakego/facade/database"
+    "github.com/deatil/lakego-doak/lakego/queue"
+    "github.com/deatil/lakego-doak/lakego/queue/failed"
+    "github.com/deatil/lakego-doak/lakego/queue/interfaces"
+    syncDriver "github.com/deatil/lakego-doak/lakego/queue/driver/sync"
+    redisDriver "github.com/deatil/lakego-doak/lakego/queue/driver/redis"
+    memoryDriver "github.com/deatil/lakego-doak/lakego/queue/driver/memory"
+    databaseDriver "github.com/deatil/lakego-doak/lakego/queue/driver/database"
+)
+
+/**
+ * 队列
+ *
+ * queue.Dispatch(&SendMail{To: "a@example.com"})
+ * queue.Dispatch(&SendMail{}, queue.OnQueue("mail"), queue.Delay(time.Minute), queue.Tries(3))
+ * queue.Connection("redis").Dispatch(&SendMail{})
+ *
+ * 失败的任务保存在配置 failed 的存储中，没有配置时只记录日志
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+
+var (
+    // 锁
+    defaultMu sync.Mutex
+
+    // 默认队列
+    defaultQueue *queue.Queue
+)
+
+// 初始化
+func init() {
+    // 注册默认
+    registerDriver()
+}
+
+// 默认队列，第一次使用时创建
+// 没有队列配置的应用导入时不会报错
+func Default() *queue.Queue {
+    defaultMu.Lock()
+    defer defaultMu.Unlock()
+
+    if defaultQueue == nil {
+        defaultQueue = New()
+    }
+
+    return defaultQueue
+}
+
+// 实例化，驱动默认只创建一次，推送及执行共用同一个驱动
+func New(once ...bool) *queue.Queue {
+    name := GetDefaultConnection()
+
+    return Connection(name, once...)
+}
+
+// 选择连接
+func Connection(name string, once ...bool) *queue.Queue {
+    o := true
+    if len(once) > 0 {
+        o = once[0]
+    }
+
+    conf := config.New("queue")
+
+    // 连接列表
+    cfg := array.ArrayFrom(conf.GetStringMap("connections"))
+
+    // 转为小写
+    name = strings.ToLower(name)
+
+    if !cfg.Has(name) {
+        panic("队列连接[" + name + "]配置不存在")
+    }
+
+    // 配置
+    driverConf := cfg.Value(name).ToStringMap()
+    driverType := cfg.Value(name + ".type").ToString()
+
+    driver := register.
+        NewManagerWithPrefix("queue").
+        GetRegister(driverType, driverConf, o)
+    if driver == nil {
+        panic("队列驱动[" + driverType + "]没有被注册")
+    }
+
+    q := queue.New(driver.(interfaces.Driver), driverConf).
+        WithConnection(name).
+        WithFailedStore(FailedStore()).
+        WithQueue(cfg.Value(name + ".queue").ToString()).
+        WithTimeout(cfg.Value(name + ".timeout").ToDuration())
+
+    if err := q.CheckTimeout(cfg.Value(name + ".timeout").ToDuration()); err != nil {
+        panic("队列连接[" + name + "]配置错误：" + err.Error())
+    }
+
+    if cfg.Has(name + ".tries") {
+        q.WithTries(cfg.Value(name + ".tries").ToInt())
+    }
+
+    backoff := make([]time.Duration, 0)
+    for _, v := range cfg.Value(name + ".backoff").ToStringSlice() {
+        backoff = append(backoff, goch.ToDuration(v))
+    }
+    q.WithBackoff(backoff...)
+
+    return q
+}
+
+// 默认连接
+func GetDefaultConnection() string {
+    return config.New("queue").GetString("default")
+}
+
+// 失败任务存储，没有配置时返回 nil
+func FailedStore() interfaces.FailedStore {
+    conf := config.New("queue").GetStringMap("failed")
+
+    cfg := array.ArrayFrom(conf)
+
+    storeType := cfg.Value("type").ToString()
+    if storeType == "" {
+        return nil
+    }
+
+    store := register.
+        NewManagerWithPrefix("queue-failed").
+        GetRegister(storeType, conf, true)
+    if store == nil {
+        panic("失败任务存储[" + storeType + "]没有被注册")
+    }
+
+    return store.(interfaces.FailedStore)
+}
+
+// 推送任务
+func Dispatch(job queue.Job, opts ...queue.Option) error {
+    return Default().Dispatch(job, opts...)
+}
+
+// 推送任务
+func DispatchContext(ctx context.Context, job queue.Job, opts ...queue.Option) error {
+    return Default().DispatchContext(ctx, job, opts...)
+}
+
+// 延迟推送任务
+func Later(delay time.Duration, job queue.Job, opts ...queue.Option) error {
+    return Default().Later(delay, job, opts...)
+}
+
+// 注册
+func registerDriver() {
+    register.
+        NewManagerWithPrefix("queue").
+        RegisterMany(map[string]func(map[string]any) any {
+            // 同步
+            "sync": func(conf map[string]any) any {
+                return syncDriver.New()
+            },
+
+            // 内存
+            "memory": func(conf map[string]any) any {
+                return memoryDriver.New()
+            },
+
+            // redis
+            "redis": func(conf map[string]any) any {
+                cfg := array.ArrayFrom(conf)
+
+                var client = redis.Default
+                if connection := cfg.Value("connection").ToString(); connection != "" {
+                    client = redis.Connect(connection)
+                }
+
+                return redisDriver.New(
+                    client.GetClient(),
+                    cfg.Value("prefix").ToString(),
+                    cfg.Value("retry-after").ToDuration(),
+                )
+            },
+
+            // 数据库
+            "database": func(conf map[string]any) any {
+                cfg := array.ArrayFrom(conf)
+
+                var db = database.Default
+                if connection := cfg.Value("connection").ToString(); connection != "" {
+                    db = database.NewWithType(connection)
+                }
+
+                return databaseDriver.New(
+                    db,
+                    cfg.Value("table").ToString(),
+                    cfg.Value("retry-after").ToDuration(),
+                )
+            },
+        })
+
+    // 注册失败任务存储
+    register.
+        NewManagerWithPrefix("queue-failed").
+        RegisterMany(map[string]func(map[string]any) any {
+            // 内存
+            "memory": func(conf map[string]any) any {
+                return failed.NewMemory()
+            },
+
+            // 数据库
+            "database": func(conf map[string]any) any {
+                cfg := array.ArrayFrom(conf)
+
+                var db = database.Default
+                if connection := cfg.Value("connection").ToString(); connection != "" {
+                    db = database.NewWithType(connection)
+                }
+
+                return failed.NewDatabase(db, cfg.Value("table").ToString())
+            },
+        })
+}
@@ -0,0 +1,74 @@
+package schedule
+
+import (
+    "github.com/deatil/lakego-doak/lakego/path"
+    "github.com/deatil/lakego-doak/lakego/array"
+    "github.com/deatil/lakego-doak/lakego/register"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+    "github.com/deatil/lakego-doak/lakego/schedule/history"
+    "github.com/deatil/lakego-doak/lakego/schedule/interfaces"
+)
+
+/**
+ * 计划任务执行记录存储
+ *
+ * 使用 server 配置的 schedule.history，type 可选 memory, file 及 database
+ * limit 为每个任务保留的记录数量，database 可以使用 max-age 设置保留时间，比如 720h
+ * database 由服务提供者注册，使用时才获取数据库连接
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+
+// 初始化
+func init() {
+    // 注册默认
+    registerHistoryStore()
+}
+
+// 执行记录存储，没有配置时返回 nil
+func HistoryStore() interfaces.HistoryStore {
+    conf := config.New("server").GetStringMap("schedule.history")
+
+    cfg := array.ArrayFrom(conf)
+
+    storeType := cfg.Value("type").ToString()
+    if storeType == "" {
+        return nil
+    }
+
+    store := register.
+        NewManagerWithPrefix("schedule-history").
+        GetRegister(storeType, conf, true)
+    if store == nil {
+        panic("计划任务执行记录存储[" + storeType + "]没有被注册")
+    }
+
+    return store.(interfaces.HistoryStore)
+}
+
+// 注册
+func registerHistoryStore() {
+    register.
+        NewManagerWithPrefix("schedule-history").
+        RegisterMany(map[string]func(map[string]any) any {
+            // 内存
+            "memory": func(conf map[string]any) any {
+                cfg := array.ArrayFrom(conf)
+
+                return history.NewMemory(cfg.Value("limit").ToInt())
+            },
+
+            // 文件
+            "file": func(conf map[string]any) any {
+                cfg := array.ArrayFrom(conf)
+
+                file := cfg.Value("path").ToString()
+                if file == "" {
+                    file = "{storage}/schedule/history.log"
+                }
+
+                return history.NewFile(path.FormatPath(file), cfg.Value("limit").ToInt())
+            },
+        })
+}
@@ -0,0 +1,116 @@
+package gmq
+
+import (
+    "fmt"
+    "log"
+    "sync"
+    "runtime/debug"
+)
+
+/**
+ * 处理业务出错
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type HandlerError struct {
+    // 主题
+    Topic string
+
+    // 订阅的主题，可能为通配主题
+    Pattern string
+
+    // 内容
+    Value any
+
+    // 错误
+    Err error
+
+    // 是否为 panic
+    Panic bool
+
+    // panic 时的调用栈
+    Stack []byte
+}
+
+// 错误信息
+func (this *HandlerError) Error() string {
+    if this.Panic {
+        return fmt.Sprintf("gmq: handler panic on topic %s: %v", this.Topic, this.Err)
+    }
+
+    return fmt.Sprintf("gmq: handler error on topic %s: %v", this.Topic, this.Err)
+}
+
+// 原始错误
+func (this *HandlerError) Unwrap() error {
+    return this.Err
+}
+
+// 错误处理
+type ErrorHook func(*HandlerError)
+
+var (
+    // 锁
+    hookMu sync.RWMutex
+
+    // 新建 GMQ 使用的错误处理
+    defaultErrorHook ErrorHook = DefaultErrorHook
+)
+
+// 设置新建 GMQ 使用的错误处理，服务提供者可设置为使用日志记录
+func SetDefaultErrorHook(hook ErrorHook) {
+    hookMu.Lock()
+    defer hookMu.Unlock()
+
+    defaultErrorHook = hook
+}
+
+// 新建 GMQ 使用的错误处理
+func GetDefaultErrorHook() ErrorHook {
+    hookMu.RLock()
+    defer hookMu.RUnlock()
+
+    return defaultErrorHook
+}
+
+// 默认使用标准库日志输出
+func DefaultErrorHook(err *HandlerError) {
+    log.Printf("%s, pattern: %s, payload: %+v\n", err.Error(), err.Pattern, err.Value)
+
+    if err.Panic {
+        log.Printf("%s\n", err.Stack)
+    }
+}
+
+// 运行处理业务，捕获 panic
+func callHandler(sub *Subscription, payload Payload) (herr *HandlerError) {
+    defer func() {
+        if r := recover(); r != nil {
+            err, ok := r.(error)
+            if !ok {
+                err = fmt.Errorf("%v", r)
+            }
+
+            herr = &HandlerError{
+                Topic:   payload.Topic,
+                Pattern: sub.topic,
+                Value:   payload.Value,
+                Err:     err,
+                Panic:   true,
+                Stack:   debug.Stack(),
+            }
+        }
+    }()
+
+    if err := sub.handler(payload.Value); err != nil {
+        return &HandlerError{
+            Topic:   payload.Topic,
+            Pattern: sub.topic,
+            Value:   payload.Value,
+            Err:     err,
+        }
+    }
+
+    return nil
+}
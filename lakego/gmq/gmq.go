@@ -1,9 +1,27 @@
 package gmq
 
 import (
+    "sort"
+    "sync"
     "errors"
+    "context"
+    "runtime"
 )
 
+var (
+    // 未运行
+    ErrNotRunning = errors.New("GMQ is not running yet")
+
+    // 已关闭
+    ErrClosed = errors.New("GMQ is closed")
+
+    // 队列已满
+    ErrQueueFull = errors.New("GMQ queue is full")
+)
+
+// 默认队列大小
+const DefaultQueueSize = 100
+
 // 内容
 type Payload struct {
     // 主题
@@ -13,30 +31,73 @@ type Payload struct {
     Value any
 }
 
-// 处理业务
-type Handler func(value any)
+// 处理业务，返回的错误及 panic 会交给错误处理
+type Handler func(value any) error
 
 // 消息中间件
+// mq := gmq.New().WithWorkers(4).WithQueueSize(100)
+// mq.Start()
+// sub := mq.Subscribe("user.*", func(value any) error { return nil })
+// mq.Publish("user", data)
+// sub.Unsubscribe()
+// mq.Shutdown(ctx)
 type GMQ struct {
+    // 运行状态锁
+    mu sync.RWMutex
+
+    // 订阅锁
+    subMu sync.RWMutex
+
     // 载荷
     payload chan Payload
 
+    // 订阅列表
+    handles map[string][]*Subscription
+
+    // 通配主题订阅列表
+    patterns map[string][]*Subscription
+
+    // 错误处理
+    errorHook ErrorHook
+
+    // 订阅 ID
+    nextID uint64
+
+    // 处理协程数量
+    workers int
+
+    // 队列大小
+    queueSize int
+
+    // 处理协程
+    wg sync.WaitGroup
+
+    // 发布中的消息
+    pubWg sync.WaitGroup
+
     // 退出
-    quit chan bool
+    quit chan struct{}
 
-    // 列表
-    handles map[string][]Handler
+    // 关闭完成
+    done chan struct{}
 
     // 是否运行
     running bool
+
+    // 是否关闭
+    closed bool
 }
 
 // 新建GMQ
 func NewGMQ() *GMQ {
     return &GMQ{
-        payload: make(chan Payload),
-        quit:    make(chan bool),
-        handles: make(map[string][]Handler),
+        handles:   make(map[string][]*Subscription),
+        patterns:  make(map[string][]*Subscription),
+        errorHook: GetDefaultErrorHook(),
+        workers:   runtime.NumCPU(),
+        queueSize: DefaultQueueSize,
+        quit:      make(chan struct{}),
+        done:      make(chan struct{}),
     }
 }
 
@@ -45,63 +106,301 @@ func New() *GMQ {
     return NewGMQ()
 }
 
-// 发布
+// 设置处理协程数量，运行前设置
+func (this *GMQ) WithWorkers(workers int) *GMQ {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if workers > 0 && !this.running {
+        this.workers = workers
+    }
+
+    return this
+}
+
+// 设置队列大小，队列满时发布会等待
+func (this *GMQ) WithQueueSize(size int) *GMQ {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if size >= 0 && !this.running {
+        this.queueSize = size
+    }
+
+    return this
+}
+
+// 设置错误处理
+func (this *GMQ) WithErrorHook(hook ErrorHook) *GMQ {
+    this.subMu.Lock()
+    defer this.subMu.Unlock()
+
+    this.errorHook = hook
+
+    return this
+}
+
+// 发布，队列满时等待
 func (this *GMQ) Publish(topic string, data any) error {
-    if !this.running {
-        return errors.New("GMQ is not running yet")
+    return this.PublishContext(context.Background(), topic, data)
+}
+
+// 发布，队列满时等待到 ctx 结束
+func (this *GMQ) PublishContext(ctx context.Context, topic string, data any) error {
+    if err := this.begin(); err != nil {
+        return err
+    }
+    defer this.pubWg.Done()
+
+    select {
+        case this.payload <- Payload{topic, data}:
+            return nil
+
+        case <-this.quit:
+            return ErrClosed
+
+        case <-ctx.Done():
+            return ctx.Err()
+    }
+}
+
+// 发布，队列满时直接返回 ErrQueueFull
+func (this *GMQ) TryPublish(topic string, data any) error {
+    if err := this.begin(); err != nil {
+        return err
+    }
+    defer this.pubWg.Done()
+
+    select {
+        case this.payload <- Payload{topic, data}:
+            return nil
+
+        default:
+            return ErrQueueFull
+    }
+}
+
+// 开始发布，关闭时会等待发布中的消息
+func (this *GMQ) begin() error {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    if err := this.check(); err != nil {
+        return err
     }
 
-    this.payload <- Payload{topic, data}
+    this.pubWg.Add(1)
 
     return nil
 }
 
-// 订阅
-func (this *GMQ) Subscribe(topic string, handler Handler) {
-    if nil == this.handles {
-        this.handles = make(map[string][]Handler)
+// 检测运行状态
+func (this *GMQ) check() error {
+    if this.closed {
+        return ErrClosed
+    }
+
+    if !this.running {
+        return ErrNotRunning
+    }
+
+    return nil
+}
+
+// 订阅，主题支持通配符 user.* 及 order.#
+func (this *GMQ) Subscribe(topic string, handler Handler) *Subscription {
+    this.subMu.Lock()
+    defer this.subMu.Unlock()
+
+    this.nextID++
+
+    sub := &Subscription{
+        id:      this.nextID,
+        topic:   topic,
+        handler: handler,
+        gmq:     this,
+    }
+
+    list := this.list(topic)
+    list[topic] = append(list[topic], sub)
+
+    return sub
+}
+
+// 主题对应的订阅列表
+func (this *GMQ) list(topic string) map[string][]*Subscription {
+    if IsPattern(topic) {
+        return this.patterns
+    }
+
+    return this.handles
+}
+
+// 取消订阅
+func (this *GMQ) Unsubscribe(sub *Subscription) bool {
+    if sub == nil {
+        return false
     }
 
-    if nil == this.handles[topic] {
-        this.handles[topic] = []Handler{handler}
-    } else {
-        this.handles[topic] = append(this.handles[topic], handler)
+    this.subMu.Lock()
+    defer this.subMu.Unlock()
+
+    list := this.list(sub.topic)
+
+    subs := list[sub.topic]
+    for i, s := range subs {
+        if s.id != sub.id {
+            continue
+        }
+
+        newSubs := make([]*Subscription, 0, len(subs) - 1)
+        newSubs = append(newSubs, subs[:i]...)
+        newSubs = append(newSubs, subs[i+1:]...)
+
+        if len(newSubs) == 0 {
+            delete(list, sub.topic)
+        } else {
+            list[sub.topic] = newSubs
+        }
+
+        return true
     }
+
+    return false
+}
+
+// 主题的订阅，按订阅顺序排列
+func (this *GMQ) subscriptions(topic string) []*Subscription {
+    this.subMu.RLock()
+    defer this.subMu.RUnlock()
+
+    subs := make([]*Subscription, 0, len(this.handles[topic]))
+    subs = append(subs, this.handles[topic]...)
+
+    if len(this.patterns) == 0 {
+        return subs
+    }
+
+    for pattern, patternSubs := range this.patterns {
+        if MatchTopic(pattern, topic) {
+            subs = append(subs, patternSubs...)
+        }
+    }
+
+    sort.Slice(subs, func(i, j int) bool {
+        return subs[i].id < subs[j].id
+    })
+
+    return subs
 }
 
 // 处理业务
-func (this *GMQ) handle(value any, handlers []Handler) {
-    for _, handler := range handlers {
-        handler(value)
+func (this *GMQ) handle(payload Payload) {
+    for _, sub := range this.subscriptions(payload.Topic) {
+        if err := callHandler(sub, payload); err != nil {
+            this.reportError(err)
+        }
     }
 }
 
-// 运行
-func (this *GMQ) Run() {
-    if this.running {
+// 错误处理
+func (this *GMQ) reportError(err *HandlerError) {
+    this.subMu.RLock()
+    hook := this.errorHook
+    this.subMu.RUnlock()
+
+    if hook == nil {
         return
     }
 
+    // 错误处理出错时不影响后续业务
+    defer func() {
+        recover()
+    }()
+
+    hook(err)
+}
+
+// 处理协程
+func (this *GMQ) worker() {
+    defer this.wg.Done()
+
+    for payload := range this.payload {
+        this.handle(payload)
+    }
+}
+
+// 启动处理协程，不阻塞
+func (this *GMQ) Start() error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if this.closed {
+        return ErrClosed
+    }
+
+    if this.running {
+        return nil
+    }
+
+    this.payload = make(chan Payload, this.queueSize)
+
+    this.wg.Add(this.workers)
+    for i := 0; i < this.workers; i++ {
+        go this.worker()
+    }
+
     // 设置为运行
     this.running = true
 
-    for {
-        select {
-            case v := <-this.payload:
-                if nil != this.handles[v.Topic] {
-                    go this.handle(v.Value, this.handles[v.Topic])
-                }
-            case v := <-this.quit:
-                if v {
-                    close(this.payload)
-                    close(this.quit)
-                    break
-                }
+    return nil
+}
+
+// 运行，阻塞到关闭完成
+func (this *GMQ) Run() {
+    if this.Start() != nil {
+        return
+    }
+
+    <-this.done
+}
+
+// 关闭，不再接收消息，等待已发布的消息处理完成
+// ctx 结束时直接返回 ctx.Err()，处理协程会继续在后台完成
+func (this *GMQ) Shutdown(ctx context.Context) error {
+    this.mu.Lock()
+
+    if !this.closed {
+        this.closed = true
+
+        close(this.quit)
+
+        if this.running {
+            go func() {
+                // 等待发布中的消息，之后才能关闭队列
+                this.pubWg.Wait()
+                close(this.payload)
+
+                this.wg.Wait()
+                close(this.done)
+            }()
+        } else {
+            close(this.done)
         }
     }
+
+    this.mu.Unlock()
+
+    select {
+        case <-this.done:
+            return nil
+
+        case <-ctx.Done():
+            return ctx.Err()
+    }
 }
 
 // 关闭
-func (this *GMQ) Close()  {
-    this.quit <- true
+func (this *GMQ) Close() {
+    this.Shutdown(context.Background())
 }
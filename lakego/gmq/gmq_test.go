@@ -0,0 +1,133 @@
+package gmq
+
+import (
+    "sync"
+    "time"
+    "errors"
+    "context"
+    "testing"
+    "sync/atomic"
+)
+
+func Test_ErrorHook(t *testing.T) {
+    assert := assertT(t)
+
+    var mu sync.Mutex
+    errs := make([]*HandlerError, 0)
+
+    mq := New().WithWorkers(1).WithErrorHook(func(err *HandlerError) {
+        mu.Lock()
+        defer mu.Unlock()
+
+        errs = append(errs, err)
+    })
+    mq.Start()
+
+    errFailed := errors.New("failed")
+
+    mq.Subscribe("user.*", func(value any) error {
+        return errFailed
+    })
+    mq.Subscribe("user.created", func(value any) error {
+        panic("boom")
+    })
+
+    called := false
+    mq.Subscribe("user.created", func(value any) error {
+        called = true
+        return nil
+    })
+
+    mq.Publish("user.created", 1)
+    mq.Shutdown(context.Background())
+
+    assert(len(errs), 2, "ErrorHook count")
+    assert(called, true, "ErrorHook later handler still called")
+
+    assert(errors.Is(errs[0], errFailed), true, "ErrorHook error")
+    assert(errs[0].Panic, false, "ErrorHook not panic")
+    assert(errs[0].Topic, "user.created", "ErrorHook topic")
+    assert(errs[0].Pattern, "user.*", "ErrorHook pattern")
+    assert(errs[0].Value, 1, "ErrorHook value")
+
+    assert(errs[1].Panic, true, "ErrorHook panic")
+    assert(errs[1].Err.Error(), "boom", "ErrorHook panic error")
+    assert(len(errs[1].Stack) > 0, true, "ErrorHook panic stack")
+    assert(errs[1].Pattern, "user.created", "ErrorHook panic pattern")
+}
+
+func Test_Workers(t *testing.T) {
+    assert := assertT(t)
+
+    mq := New().WithWorkers(2).WithQueueSize(10)
+    mq.Start()
+
+    var running, max, done int32
+
+    mq.Subscribe("job", func(value any) error {
+        current := atomic.AddInt32(&running, 1)
+        for {
+            old := atomic.LoadInt32(&max)
+            if current <= old || atomic.CompareAndSwapInt32(&max, old, current) {
+                break
+            }
+        }
+
+        time.Sleep(20 * time.Millisecond)
+
+        atomic.AddInt32(&running, -1)
+        atomic.AddInt32(&done, 1)
+        return nil
+    })
+
+    for i := 0; i < 6; i++ {
+        assert(mq.Publish("job", i), nil, "Workers publish")
+    }
+
+    assert(mq.Shutdown(context.Background()), nil, "Workers shutdown")
+
+    assert(atomic.LoadInt32(&done), int32(6), "Workers done")
+    assert(atomic.LoadInt32(&max), int32(2), "Workers bounded concurrency")
+}
+
+func Test_Shutdown(t *testing.T) {
+    assert := assertT(t)
+
+    assert(New().Publish("job", 1), ErrNotRunning, "Publish before Start")
+
+    mq := New().WithWorkers(1).WithQueueSize(1)
+    mq.Start()
+
+    started := make(chan struct{}, 1)
+    release := make(chan struct{})
+
+    var done int32
+    mq.Subscribe("job", func(value any) error {
+        started <- struct{}{}
+        <-release
+
+        atomic.AddInt32(&done, 1)
+        return nil
+    })
+
+    assert(mq.Publish("job", 1), nil, "Publish")
+    <-started
+
+    assert(mq.TryPublish("job", 2), nil, "TryPublish queued")
+    assert(mq.TryPublish("job", 3), ErrQueueFull, "TryPublish queue full")
+
+    // 超时后直接返回，处理协程继续运行
+    ctx, cancel := context.WithTimeout(context.Background(), 20 * time.Millisecond)
+    defer cancel()
+
+    assert(mq.Shutdown(ctx), context.DeadlineExceeded, "Shutdown deadline")
+    assert(atomic.LoadInt32(&done), int32(0), "Shutdown deadline not drained")
+    assert(mq.Publish("job", 4), ErrClosed, "Publish after Shutdown")
+
+    close(release)
+
+    // 已发布的消息处理完成后关闭
+    assert(mq.Shutdown(context.Background()), nil, "Shutdown drain")
+    assert(atomic.LoadInt32(&done), int32(2), "Shutdown drained in-flight")
+    assert(mq.Start(), ErrClosed, "Start after Shutdown")
+}
@@ -0,0 +1,36 @@
+package gmq
+
+/**
+ * 订阅
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Subscription struct {
+    // 订阅 ID
+    id uint64
+
+    // 主题
+    topic string
+
+    // 处理业务
+    handler Handler
+
+    // 所属 GMQ
+    gmq *GMQ
+}
+
+// 订阅 ID
+func (this *Subscription) ID() uint64 {
+    return this.id
+}
+
+// 主题
+func (this *Subscription) Topic() string {
+    return this.topic
+}
+
+// 取消订阅
+func (this *Subscription) Unsubscribe() bool {
+    return this.gmq.Unsubscribe(this)
+}
@@ -0,0 +1,118 @@
+package gmq
+
+import (
+    "sync"
+    "errors"
+    "context"
+    "testing"
+    "reflect"
+)
+
+func assertT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+// 记录收到的消息
+type received struct {
+    mu    sync.Mutex
+    items []string
+}
+
+func (this *received) handler(name string) Handler {
+    return func(value any) error {
+        this.mu.Lock()
+        defer this.mu.Unlock()
+
+        this.items = append(this.items, name + ":" + value.(string))
+        return nil
+    }
+}
+
+func (this *received) list() []string {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    return append([]string{}, this.items...)
+}
+
+func Test_Subscribe(t *testing.T) {
+    assert := assertT(t)
+
+    mq := New().WithWorkers(1)
+    assert(mq.Start(), nil, "Start")
+
+    got := &received{}
+
+    exact := mq.Subscribe("user.created", got.handler("exact"))
+    one := mq.Subscribe("user.*", got.handler("one"))
+    many := mq.Subscribe("order.#", got.handler("many"))
+
+    assert(exact.Topic(), "user.created", "Subscription Topic")
+    assert(one.ID() > exact.ID(), true, "Subscription ID")
+
+    mq.Publish("user.created", "a")
+    mq.Publish("user.profile.updated", "b")
+    mq.Publish("order", "c")
+    mq.Publish("order.item.added", "d")
+
+    assert(mq.Shutdown(context.Background()), nil, "Shutdown")
+
+    assert(got.list(), []string{"exact:a", "one:a", "many:c", "many:d"}, "Subscribe received")
+    assert(many.Topic(), "order.#", "Subscription pattern topic")
+}
+
+func Test_Unsubscribe(t *testing.T) {
+    assert := assertT(t)
+
+    mq := New().WithWorkers(1)
+    mq.Start()
+
+    got := &received{}
+
+    first := mq.Subscribe("user.created", got.handler("first"))
+    second := mq.Subscribe("user.created", got.handler("second"))
+    pattern := mq.Subscribe("user.*", got.handler("pattern"))
+
+    assert(first.Unsubscribe(), true, "Unsubscribe")
+    assert(first.Unsubscribe(), false, "Unsubscribe twice")
+    assert(pattern.Unsubscribe(), true, "Unsubscribe pattern")
+    assert(mq.Unsubscribe(nil), false, "Unsubscribe nil")
+
+    mq.Publish("user.created", "a")
+    mq.Shutdown(context.Background())
+
+    assert(got.list(), []string{"second:a"}, "Unsubscribe received")
+
+    assert(second.Unsubscribe(), true, "Unsubscribe last")
+    assert(len(mq.handles), 0, "Unsubscribe removes topic")
+    assert(len(mq.patterns), 0, "Unsubscribe removes pattern")
+}
+
+func Test_DefaultErrorHook(t *testing.T) {
+    assert := assertT(t)
+
+    var got *HandlerError
+
+    SetDefaultErrorHook(func(err *HandlerError) {
+        got = err
+    })
+    defer SetDefaultErrorHook(DefaultErrorHook)
+
+    mq := New().WithWorkers(1)
+    mq.Start()
+
+    errFailed := errors.New("failed")
+    mq.Subscribe("user", func(any) error {
+        return errFailed
+    })
+
+    mq.Publish("user", "a")
+    mq.Shutdown(context.Background())
+
+    assert(got != nil, true, "SetDefaultErrorHook used by New")
+    assert(errors.Is(got, errFailed), true, "SetDefaultErrorHook error")
+}
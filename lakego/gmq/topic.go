@@ -0,0 +1,73 @@
+package gmq
+
+import (
+    "strings"
+)
+
+// 通配符
+const (
+    // 匹配一个层级
+    WildcardOne = "*"
+
+    // 匹配零个或多个层级
+    WildcardMany = "#"
+)
+
+// 主题层级分隔符
+const topicSeparator = "."
+
+// 是否为通配主题
+func IsPattern(topic string) bool {
+    for _, part := range strings.Split(topic, topicSeparator) {
+        if part == WildcardOne || part == WildcardMany {
+            return true
+        }
+    }
+
+    return false
+}
+
+// 主题是否匹配
+// user.* 匹配 user.created，不匹配 user.profile.updated
+// order.# 匹配 order、order.paid 及 order.item.added
+func MatchTopic(pattern string, topic string) bool {
+    return matchParts(
+        strings.Split(pattern, topicSeparator),
+        strings.Split(topic, topicSeparator),
+    )
+}
+
+// 按层级匹配
+func matchParts(pattern []string, topic []string) bool {
+    for len(pattern) > 0 {
+        part := pattern[0]
+
+        if part == WildcardMany {
+            // 最后一个 # 匹配剩余全部层级
+            if len(pattern) == 1 {
+                return true
+            }
+
+            for i := 0; i <= len(topic); i++ {
+                if matchParts(pattern[1:], topic[i:]) {
+                    return true
+                }
+            }
+
+            return false
+        }
+
+        if len(topic) == 0 {
+            return false
+        }
+
+        if part != WildcardOne && part != topic[0] {
+            return false
+        }
+
+        pattern = pattern[1:]
+        topic = topic[1:]
+    }
+
+    return len(topic) == 0
+}
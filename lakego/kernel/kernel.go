@@ -2,6 +2,7 @@ package kernel
 
 import (
     "os"
+    "log"
     "net"
     "flag"
 
@@ -74,15 +75,20 @@ func (this *Kernel) runServer() {
 
 // 加载脚本
 func (this *Kernel) runCmd() {
-    this.runApp(true)
+    newApp := this.runApp(true)
 
-    if err := rootCmd.Execute(); err != nil {
+    err := rootCmd.Execute()
+
+    // 脚本执行完成后关闭应用
+    newApp.Shutdown()
+
+    if err != nil {
         os.Exit(-1)
     }
 }
 
 // 运行
-func (this *Kernel) runApp(console bool) {
+func (this *Kernel) runApp(console bool) *app.App {
     newApp := app.New()
 
     // 导入服务提供者
@@ -107,7 +113,11 @@ func (this *Kernel) runApp(console bool) {
     }
 
     // 运行
-    newApp.Run()
+    if err := newApp.Run(); err != nil {
+        log.Fatalln(err.Error())
+    }
+
+    return newApp
 }
 
 // 导入服务提供者
@@ -0,0 +1,36 @@
+package logger
+
+import (
+    "context"
+
+    "github.com/deatil/lakego-doak/lakego/logger/interfaces"
+)
+
+// router.Context 中日志的键名，用于 Set 和 Get
+const ContextKey = "lakego.logger"
+
+// 上下文中日志的键名
+type contextKey struct{}
+
+// 将日志存入上下文
+func NewContext(ctx context.Context, driver interfaces.Driver) context.Context {
+    return context.WithValue(ctx, contextKey{}, driver)
+}
+
+// 从上下文获取日志，不存在时返回 nil
+func FromContext(ctx context.Context) interfaces.Driver {
+    if ctx == nil {
+        return nil
+    }
+
+    if driver, ok := ctx.Value(contextKey{}).(interfaces.Driver); ok {
+        return driver
+    }
+
+    // router.Context 的 Value 会读取 Set 设置的数据
+    if driver, ok := ctx.Value(ContextKey).(interfaces.Driver); ok {
+        return driver
+    }
+
+    return nil
+}
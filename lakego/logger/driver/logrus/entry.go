@@ -0,0 +1,120 @@
+package logrus
+
+import (
+    "context"
+
+    "github.com/sirupsen/logrus"
+
+    "github.com/deatil/lakego-doak/lakego/logger/interfaces"
+)
+
+/**
+ * 带自定义数据的日志
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type FieldsEntry struct {
+    // logrus 数据
+    entry *logrus.Entry
+}
+
+// 构造方法
+func NewFieldsEntry(entry *logrus.Entry) *FieldsEntry {
+    return &FieldsEntry{
+        entry: entry,
+    }
+}
+
+// 获取 logrus 数据
+func (this *FieldsEntry) GetEntry() *logrus.Entry {
+    return this.entry
+}
+
+// 设置自定义变量
+func (this *FieldsEntry) WithField(key string, value any) interfaces.Entry {
+    return NewFieldsEntry(this.entry.WithField(key, value))
+}
+
+// 批量设置自定义变量
+func (this *FieldsEntry) WithFields(fields map[string]any) interfaces.Entry {
+    return NewFieldsEntry(this.entry.WithFields(Fields(fields)))
+}
+
+// 设置错误信息
+func (this *FieldsEntry) WithError(err error) interfaces.Entry {
+    return NewFieldsEntry(this.entry.WithError(err))
+}
+
+// 设置上下文
+func (this *FieldsEntry) WithContext(ctx context.Context) interfaces.Entry {
+    return NewFieldsEntry(this.entry.WithContext(ctx))
+}
+
+// ========
+
+func (this *FieldsEntry) Trace(args ...any) {
+    this.entry.Trace(args...)
+}
+
+func (this *FieldsEntry) Debug(args ...any) {
+    this.entry.Debug(args...)
+}
+
+func (this *FieldsEntry) Info(args ...any) {
+    this.entry.Info(args...)
+}
+
+func (this *FieldsEntry) Warn(args ...any) {
+    this.entry.Warn(args...)
+}
+
+func (this *FieldsEntry) Warning(args ...any) {
+    this.entry.Warning(args...)
+}
+
+func (this *FieldsEntry) Error(args ...any) {
+    this.entry.Error(args...)
+}
+
+func (this *FieldsEntry) Fatal(args ...any) {
+    this.entry.Fatal(args...)
+}
+
+func (this *FieldsEntry) Panic(args ...any) {
+    this.entry.Panic(args...)
+}
+
+// ========
+
+func (this *FieldsEntry) Tracef(template string, args ...any) {
+    this.entry.Tracef(template, args...)
+}
+
+func (this *FieldsEntry) Debugf(template string, args ...any) {
+    this.entry.Debugf(template, args...)
+}
+
+func (this *FieldsEntry) Infof(template string, args ...any) {
+    this.entry.Infof(template, args...)
+}
+
+func (this *FieldsEntry) Warnf(template string, args ...any) {
+    this.entry.Warnf(template, args...)
+}
+
+func (this *FieldsEntry) Warningf(template string, args ...any) {
+    this.entry.Warningf(template, args...)
+}
+
+func (this *FieldsEntry) Errorf(template string, args ...any) {
+    this.entry.Errorf(template, args...)
+}
+
+func (this *FieldsEntry) Fatalf(template string, args ...any) {
+    this.entry.Fatalf(template, args...)
+}
+
+func (this *FieldsEntry) Panicf(template string, args ...any) {
+    this.entry.Panicf(template, args...)
+}
@@ -0,0 +1,54 @@
+package caller
+
+import (
+    "fmt"
+    "path"
+    "runtime"
+    "strings"
+)
+
+// 跳过的包
+var skipPackages = []string{
+    "github.com/sirupsen/logrus",
+    "github.com/deatil/lakego-doak/lakego/logger",
+    "github.com/deatil/lakego-doak/lakego/facade/logger",
+    "github.com/deatil/lakego-doak/lakego/database/driver",
+    "gorm.io/gorm",
+}
+
+// 添加需要跳过的包，用于自定义的日志封装
+func Skip(pkg ...string) {
+    skipPackages = append(skipPackages, pkg...)
+}
+
+// 获取调用日志的位置，格式为 file.go:line
+func Caller() string {
+    pcs := make([]uintptr, 32)
+    n := runtime.Callers(2, pcs)
+
+    frames := runtime.CallersFrames(pcs[:n])
+    for {
+        frame, more := frames.Next()
+
+        if !isSkipped(frame.Function) {
+            return fmt.Sprintf("%s:%d", path.Base(frame.File), frame.Line)
+        }
+
+        if !more {
+            break
+        }
+    }
+
+    return ""
+}
+
+// 是否跳过
+func isSkipped(function string) bool {
+    for _, pkg := range skipPackages {
+        if strings.HasPrefix(function, pkg + ".") || strings.HasPrefix(function, pkg + "/") {
+            return true
+        }
+    }
+
+    return false
+}
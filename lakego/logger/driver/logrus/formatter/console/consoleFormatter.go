@@ -0,0 +1,93 @@
+package console
+
+import (
+    "fmt"
+    "sort"
+    "bytes"
+
+    "github.com/sirupsen/logrus"
+
+    "github.com/deatil/lakego-doak/lakego/color"
+    "github.com/deatil/lakego-doak/lakego/logger/driver/logrus/formatter/caller"
+)
+
+// 等级颜色
+var levelColors = map[logrus.Level]string{
+    logrus.PanicLevel: "red",
+    logrus.FatalLevel: "red",
+    logrus.ErrorLevel: "red",
+    logrus.WarnLevel:  "yellow",
+    logrus.InfoLevel:  "green",
+    logrus.DebugLevel: "cyan",
+    logrus.TraceLevel: "white",
+}
+
+/**
+ * 命令行彩色格式化
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type ConsoleFormatter struct {
+    // 时间格式
+    TimestampFormat string
+
+    // 关闭颜色
+    DisableColors bool
+}
+
+func (this *ConsoleFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+    var b *bytes.Buffer
+    if entry.Buffer != nil {
+        b = entry.Buffer
+    } else {
+        b = &bytes.Buffer{}
+    }
+
+    timestampFormat := this.TimestampFormat
+    if timestampFormat == "" {
+        timestampFormat = "2006-01-02 15:04:05"
+    }
+
+    level := fmt.Sprintf("[%s]", entry.Level.String())
+
+    b.WriteString(this.paint("white", entry.Time.Format(timestampFormat)))
+    b.WriteString(" ")
+    b.WriteString(this.paint(levelColors[entry.Level], level))
+    b.WriteString(" ")
+    b.WriteString(entry.Message)
+
+    // 自定义数据按名称排序
+    keys := make([]string, 0, len(entry.Data))
+    for k, _ := range entry.Data {
+        keys = append(keys, k)
+    }
+    sort.Strings(keys)
+
+    for _, k := range keys {
+        b.WriteString(" ")
+        b.WriteString(this.paint("cyan", k))
+        b.WriteString("=")
+        fmt.Fprint(b, entry.Data[k])
+    }
+
+    if entry.HasCaller() {
+        if pos := caller.Caller(); pos != "" {
+            b.WriteString(" ")
+            b.WriteString(this.paint("magenta", "(" + pos + ")"))
+        }
+    }
+
+    b.WriteString("\n")
+
+    return b.Bytes(), nil
+}
+
+// 设置颜色
+func (this *ConsoleFormatter) paint(name string, msg string) string {
+    if this.DisableColors || name == "" {
+        return msg
+    }
+
+    return color.New(color.ForegroundOption(name)).Sprint(msg)
+}
@@ -5,6 +5,8 @@ import (
     "runtime"
     "github.com/sirupsen/logrus"
     "github.com/deatil/lakego-doak/lakego/logger/driver/logrus/formatter/normal"
+    "github.com/deatil/lakego-doak/lakego/logger/driver/logrus/formatter/console"
+    "github.com/deatil/lakego-doak/lakego/logger/driver/logrus/formatter/jsonline"
 )
 
 // 正常存储格式
@@ -41,3 +43,19 @@ func JSONFormatter() logrus.Formatter {
 
     return formatter
 }
+
+// 命令行彩色格式
+func ConsoleFormatter() logrus.Formatter {
+    formatter := &console.ConsoleFormatter{
+        TimestampFormat: "2006-01-02 15:04:05",
+    }
+
+    return formatter
+}
+
+// 单行 json 格式
+func JSONLineFormatter() logrus.Formatter {
+    formatter := &jsonline.JSONLineFormatter{}
+
+    return formatter
+}
@@ -0,0 +1,79 @@
+package jsonline
+
+import (
+    "time"
+    "bytes"
+    "encoding/json"
+
+    "github.com/sirupsen/logrus"
+
+    "github.com/deatil/lakego-doak/lakego/logger/driver/logrus/formatter/caller"
+)
+
+// 默认字段
+const (
+    FieldKeyTime    = "timestamp"
+    FieldKeyLevel   = "level"
+    FieldKeyMessage = "message"
+    FieldKeyCaller  = "caller"
+)
+
+/**
+ * 单行 json 格式化
+ *
+ * {"caller":"main.go:12","level":"info","message":"logger test","timestamp":"2026-10-19T12:00:00.000+08:00"}
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type JSONLineFormatter struct {
+    // 时间格式
+    TimestampFormat string
+}
+
+func (this *JSONLineFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+    var b *bytes.Buffer
+    if entry.Buffer != nil {
+        b = entry.Buffer
+    } else {
+        b = &bytes.Buffer{}
+    }
+
+    data := make(logrus.Fields, len(entry.Data) + 4)
+    for k, v := range entry.Data {
+        // 和默认字段冲突时添加前缀
+        switch k {
+            case FieldKeyTime, FieldKeyLevel, FieldKeyMessage, FieldKeyCaller:
+                k = "fields." + k
+        }
+
+        if err, ok := v.(error); ok {
+            data[k] = err.Error()
+        } else {
+            data[k] = v
+        }
+    }
+
+    timestampFormat := this.TimestampFormat
+    if timestampFormat == "" {
+        timestampFormat = time.RFC3339Nano
+    }
+
+    data[FieldKeyTime] = entry.Time.Format(timestampFormat)
+    data[FieldKeyLevel] = entry.Level.String()
+    data[FieldKeyMessage] = entry.Message
+
+    if entry.HasCaller() {
+        if pos := caller.Caller(); pos != "" {
+            data[FieldKeyCaller] = pos
+        }
+    }
+
+    encoder := json.NewEncoder(b)
+    encoder.SetEscapeHTML(false)
+    if err := encoder.Encode(data); err != nil {
+        return nil, err
+    }
+
+    return b.Bytes(), nil
+}
@@ -1,14 +1,18 @@
 package logrus
 
 import (
-    "fmt"
+    "io"
+    "os"
+    "sync"
     "time"
+    "context"
     logger "log"
 
     "github.com/sirupsen/logrus"
-    "github.com/lestrrat/go-file-rotatelogs"
 
     "github.com/deatil/lakego-doak/lakego/path"
+    "github.com/deatil/lakego-doak/lakego/logger/rotate"
+    "github.com/deatil/lakego-doak/lakego/logger/interfaces"
     "github.com/deatil/lakego-doak/lakego/logger/driver/logrus/formatter"
 )
 
@@ -30,8 +34,17 @@ type (
  * @author deatil
  */
 type Logrus struct {
+    // 锁
+    mu sync.RWMutex
+
     // 配置
     Config map[string]any
+
+    // 已生成的日志
+    logger *logrus.Logger
+
+    // 日志写入
+    writer io.Writer
 }
 
 // 构造方法
@@ -39,25 +52,46 @@ func New() *Logrus {
     return &Logrus{}
 }
 
-// 设置配置
+// 设置配置，日志会在下次使用时重新生成
 func (this *Logrus) WithConfig(config map[string]any) {
+    this.mu.Lock()
+
     this.Config = config
+
+    writer := this.writer
+
+    this.logger = nil
+    this.writer = nil
+
+    this.mu.Unlock()
+
+    // 在锁外关闭旧的写入，等待正在进行的写入完成
+    closeWriter(writer)
 }
 
 // 批量设置自定义变量
-func (this *Logrus) WithFields(fields map[string]any) any {
+func (this *Logrus) WithFields(fields map[string]any) interfaces.Entry {
     data := make(Fields, len(fields))
     for k, v := range fields {
         data[k] = v
     }
 
-    return this.getLogger().WithFields(data)
+    return NewFieldsEntry(this.getLogger().WithFields(data))
 }
 
 // 设置自定义变量
-// *logrus.Entry
-func (this *Logrus) WithField(key string, value any) any {
-    return this.getLogger().WithField(key, value)
+func (this *Logrus) WithField(key string, value any) interfaces.Entry {
+    return NewFieldsEntry(this.getLogger().WithField(key, value))
+}
+
+// 设置错误信息
+func (this *Logrus) WithError(err error) interfaces.Entry {
+    return NewFieldsEntry(this.getLogger().WithError(err))
+}
+
+// 设置上下文
+func (this *Logrus) WithContext(ctx context.Context) interfaces.Entry {
+    return NewFieldsEntry(this.getLogger().WithContext(ctx))
 }
 
 // ========
@@ -215,18 +249,35 @@ func (this *Logrus) GetLevel() logrus.Level {
     return this.getLogger().GetLevel()
 }
 
-// 设置
+// 获取日志，同一配置只生成一次
 func (this *Logrus) getLogger() *logrus.Logger {
-    // 配置
-    conf := this.Config
+    this.mu.RLock()
+    log := this.logger
+    this.mu.RUnlock()
+
+    if log != nil {
+        return log
+    }
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
 
+    if this.logger == nil {
+        this.logger, this.writer = newLogger(this.Config)
+    }
+
+    return this.logger
+}
+
+// 根据配置生成日志
+func newLogger(conf map[string]any) (*logrus.Logger, io.Writer) {
     log := logrus.New()
 
     log.SetReportCaller(true)
 
     var useFormatter logrus.Formatter
 
-    formatterType := conf["formatter"].(string)
+    formatterType, _ := conf["formatter"].(string)
     switch formatterType {
         case "json":
             // json 格式
@@ -236,6 +287,14 @@ func (this *Logrus) getLogger() *logrus.Logger {
             // 文档格式
             useFormatter = formatter.TextFormatter()
 
+        case "console":
+            // 命令行彩色格式
+            useFormatter = formatter.ConsoleFormatter()
+
+        case "json-line":
+            // 单行 json 格式
+            useFormatter = formatter.JSONLineFormatter()
+
         default:
             // 正常格式
             useFormatter = formatter.NormalFormatter()
@@ -244,24 +303,9 @@ func (this *Logrus) getLogger() *logrus.Logger {
     // 设置输出样式
     log.SetFormatter(useFormatter)
 
-    // 日志目录
-    filepath := conf["filepath"].(string)
-
-    // 日志文件
-    // log_%Y%m%d.log
-    logPath := path.FormatPath(filepath)
-
-    maxAge := time.Duration(int64(conf["max-age"].(int)))
-    rotationTime := time.Duration(int64(conf["rotation-time"].(int)))
-
-    writer, err := rotatelogs.New(
-        logPath,
-        // rotatelogs.WithLinkName(baseLogPath), // 生成软链，指向最新日志文件
-        rotatelogs.WithMaxAge(maxAge * time.Hour), // 文件最大保存时间
-        rotatelogs.WithRotationTime(rotationTime * time.Hour), // 日志切割时间间隔
-    )
-    if err != nil {
-        logger.Print(fmt.Sprintf("日志配置错误：%v", err))
+    // 日志写入
+    writer := &safeWriter{
+        writer: newWriter(conf),
     }
 
     // os.Stdout || os.Stderr
@@ -270,7 +314,7 @@ func (this *Logrus) getLogger() *logrus.Logger {
     log.SetOutput(writer)
 
     // 日志等级
-    level := conf["level"].(string)
+    level, _ := conf["level"].(string)
 
     // 设置最低 loglevel
     switch level {
@@ -303,6 +347,100 @@ func (this *Logrus) getLogger() *logrus.Logger {
             log.SetLevel(logrus.TraceLevel)
     }
 
-    return log
+    return log, writer
 }
 
+// 根据配置生成日志写入
+// output 可选 stdout | stderr，默认写入文件
+func newWriter(conf map[string]any) io.Writer {
+    output, _ := conf["output"].(string)
+    switch output {
+        case "stdout":
+            return os.Stdout
+
+        case "stderr":
+            return os.Stderr
+    }
+
+    // 日志目录
+    filepath, _ := conf["filepath"].(string)
+
+    // 日志文件
+    // log_%Y%m%d.log
+    logPath := path.FormatPath(filepath)
+
+    if logPath == "" {
+        logger.Print("日志配置错误：filepath 不能为空")
+
+        // 配置错误时输出到 stderr
+        return os.Stderr
+    }
+
+    maxAge, _ := conf["max-age"].(int)
+    rotationTime, _ := conf["rotation-time"].(int)
+    maxSize, _ := conf["max-size"].(int)
+    maxBackups, _ := conf["max-backups"].(int)
+    compress, _ := conf["compress"].(bool)
+    linkName, _ := conf["link-name"].(string)
+
+    if linkName != "" {
+        linkName = path.FormatPath(linkName)
+    }
+
+    return rotate.New(rotate.Options{
+        Filename:     logPath,
+        MaxAge:       time.Duration(maxAge) * time.Hour, // 文件最大保存时间
+        RotationTime: time.Duration(rotationTime) * time.Hour, // 日志切割时间间隔
+        MaxSize:      int64(maxSize) * 1024 * 1024, // 单个文件最大 MB
+        MaxBackups:   maxBackups, // 最多保留的旧文件数量
+        Compress:     compress, // 压缩旧文件
+        LinkName:     linkName, // 生成软链，指向最新日志文件
+    })
+}
+
+// 关闭日志文件
+func closeWriter(writer io.Writer) {
+    if writer == os.Stdout || writer == os.Stderr {
+        return
+    }
+
+    if closer, ok := writer.(io.Closer); ok {
+        closer.Close()
+    }
+}
+
+// 可安全关闭的日志写入
+// 关闭时等待正在进行的写入完成，关闭后的写入会被丢弃
+type safeWriter struct {
+    mu     sync.RWMutex
+    writer io.Writer
+    closed bool
+}
+
+// 写入日志
+func (this *safeWriter) Write(p []byte) (int, error) {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    if this.closed {
+        return len(p), nil
+    }
+
+    return this.writer.Write(p)
+}
+
+// 关闭日志写入
+func (this *safeWriter) Close() error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if this.closed {
+        return nil
+    }
+
+    this.closed = true
+
+    closeWriter(this.writer)
+
+    return nil
+}
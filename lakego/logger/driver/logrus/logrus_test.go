@@ -0,0 +1,110 @@
+package logrus
+
+import (
+    "sync"
+    "testing"
+    "path/filepath"
+)
+
+func testConfig(dir string) map[string]any {
+    return map[string]any{
+        "formatter":     "json",
+        "filepath":      filepath.Join(dir, "log_%Y%m%d.log"),
+        "max-age":       24,
+        "rotation-time": 24,
+        "level":         "trace",
+    }
+}
+
+func Test_LoggerCached(t *testing.T) {
+    driver := New()
+    driver.WithConfig(testConfig(t.TempDir()))
+
+    first := driver.getLogger()
+    if first != driver.getLogger() {
+        t.Error("Failed Test_LoggerCached: logger rebuilt with same config")
+    }
+
+    driver.WithConfig(testConfig(t.TempDir()))
+    if first == driver.getLogger() {
+        t.Error("Failed Test_LoggerCached: logger not rebuilt after WithConfig")
+    }
+
+    driver.WithConfig(nil)
+}
+
+func Test_WriterClosedOnReconfigure(t *testing.T) {
+    driver := New()
+    driver.WithConfig(testConfig(t.TempDir()))
+
+    entry := driver.WithField("key", "value")
+
+    // 重新配置后旧的日志仍可写入，不会写入已关闭的文件
+    driver.WithConfig(testConfig(t.TempDir()))
+    entry.Info("lakego after reconfigure")
+
+    var wg sync.WaitGroup
+    for i := 0; i < 4; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+
+            for j := 0; j < 100; j++ {
+                driver.Info("lakego concurrent")
+            }
+        }()
+    }
+
+    for i := 0; i < 10; i++ {
+        driver.WithConfig(testConfig(t.TempDir()))
+    }
+
+    wg.Wait()
+
+    driver.WithConfig(nil)
+}
+
+// 缓存的日志
+func Benchmark_LoggerCached(b *testing.B) {
+    driver := New()
+    driver.WithConfig(testConfig(b.TempDir()))
+    defer driver.WithConfig(nil)
+
+    b.ReportAllocs()
+    b.ResetTimer()
+
+    for i := 0; i < b.N; i++ {
+        driver.Info("lakego benchmark")
+    }
+}
+
+// 缓存的日志，并发写入
+func Benchmark_LoggerCachedParallel(b *testing.B) {
+    driver := New()
+    driver.WithConfig(testConfig(b.TempDir()))
+    defer driver.WithConfig(nil)
+
+    b.ReportAllocs()
+    b.ResetTimer()
+
+    b.RunParallel(func(pb *testing.PB) {
+        for pb.Next() {
+            driver.Info("lakego benchmark")
+        }
+    })
+}
+
+// 每次生成新的日志，旧版本的写法
+func Benchmark_LoggerRebuild(b *testing.B) {
+    conf := testConfig(b.TempDir())
+
+    b.ReportAllocs()
+    b.ResetTimer()
+
+    for i := 0; i < b.N; i++ {
+        log, writer := newLogger(conf)
+        log.Info("lakego benchmark")
+
+        closeWriter(writer)
+    }
+}
@@ -0,0 +1,241 @@
+package stack
+
+import (
+    "os"
+    "fmt"
+    "context"
+
+    "github.com/deatil/lakego-doak/lakego/logger"
+    "github.com/deatil/lakego-doak/lakego/logger/interfaces"
+)
+
+// 输出目标
+type target struct {
+    // 最低记录等级
+    level logger.Level
+
+    // 输出
+    entry interfaces.Entry
+}
+
+/**
+ * 多通道日志数据
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Entry struct {
+    // 输出目标
+    targets []target
+}
+
+// 生成新的数据，每个通道分别设置
+func (this *Entry) with(fn func(interfaces.Entry) interfaces.Entry) *Entry {
+    targets := make([]target, 0, len(this.targets))
+    for _, t := range this.targets {
+        targets = append(targets, target{
+            level: t.level,
+            entry: fn(t.entry),
+        })
+    }
+
+    return &Entry{
+        targets: targets,
+    }
+}
+
+// 设置自定义变量
+func (this *Entry) WithField(key string, value any) interfaces.Entry {
+    return this.with(func(entry interfaces.Entry) interfaces.Entry {
+        return entry.WithField(key, value)
+    })
+}
+
+// 批量设置自定义变量
+func (this *Entry) WithFields(fields map[string]any) interfaces.Entry {
+    return this.with(func(entry interfaces.Entry) interfaces.Entry {
+        return entry.WithFields(fields)
+    })
+}
+
+// 设置错误信息
+func (this *Entry) WithError(err error) interfaces.Entry {
+    return this.with(func(entry interfaces.Entry) interfaces.Entry {
+        return entry.WithError(err)
+    })
+}
+
+// 设置上下文
+func (this *Entry) WithContext(ctx context.Context) interfaces.Entry {
+    return this.with(func(entry interfaces.Entry) interfaces.Entry {
+        return entry.WithContext(ctx)
+    })
+}
+
+// 写入满足等级的通道
+func (this *Entry) log(level logger.Level, fn func(interfaces.Printer)) {
+    for _, t := range this.targets {
+        if t.level.Enabled(level) {
+            fn(t.entry)
+        }
+    }
+}
+
+// 写入后退出，最后一个通道的 Fatal 结束程序，其他通道以 Error 写入
+// 没有通道记录 fatal 等级时直接退出
+func (this *Entry) fatal(fatalFn func(interfaces.Printer), errorFn func(interfaces.Printer)) {
+    targets := make([]target, 0, len(this.targets))
+    for _, t := range this.targets {
+        if t.level.Enabled(logger.FatalLevel) {
+            targets = append(targets, t)
+        }
+    }
+
+    if len(targets) == 0 {
+        os.Exit(1)
+    }
+
+    for i, t := range targets {
+        if i == len(targets) - 1 {
+            fatalFn(t.entry)
+        } else {
+            errorFn(t.entry)
+        }
+    }
+}
+
+// 全部通道写入后再 panic
+// 没有通道记录 panic 等级时使用 msg 生成的信息 panic
+func (this *Entry) panic(fn func(interfaces.Printer), msg func() string) {
+    var recovered any
+
+    for _, t := range this.targets {
+        if !t.level.Enabled(logger.PanicLevel) {
+            continue
+        }
+
+        func() {
+            defer func() {
+                if r := recover(); r != nil && recovered == nil {
+                    recovered = r
+                }
+            }()
+
+            fn(t.entry)
+        }()
+    }
+
+    if recovered == nil {
+        recovered = msg()
+    }
+
+    panic(recovered)
+}
+
+// ========
+
+func (this *Entry) Trace(args ...any) {
+    this.log(logger.TraceLevel, func(p interfaces.Printer) {
+        p.Trace(args...)
+    })
+}
+
+func (this *Entry) Debug(args ...any) {
+    this.log(logger.DebugLevel, func(p interfaces.Printer) {
+        p.Debug(args...)
+    })
+}
+
+func (this *Entry) Info(args ...any) {
+    this.log(logger.InfoLevel, func(p interfaces.Printer) {
+        p.Info(args...)
+    })
+}
+
+func (this *Entry) Warn(args ...any) {
+    this.log(logger.WarnLevel, func(p interfaces.Printer) {
+        p.Warn(args...)
+    })
+}
+
+func (this *Entry) Warning(args ...any) {
+    this.log(logger.WarnLevel, func(p interfaces.Printer) {
+        p.Warning(args...)
+    })
+}
+
+func (this *Entry) Error(args ...any) {
+    this.log(logger.ErrorLevel, func(p interfaces.Printer) {
+        p.Error(args...)
+    })
+}
+
+func (this *Entry) Fatal(args ...any) {
+    this.fatal(func(p interfaces.Printer) {
+        p.Fatal(args...)
+    }, func(p interfaces.Printer) {
+        p.Error(args...)
+    })
+}
+
+func (this *Entry) Panic(args ...any) {
+    this.panic(func(p interfaces.Printer) {
+        p.Panic(args...)
+    }, func() string {
+        return fmt.Sprint(args...)
+    })
+}
+
+// ========
+
+func (this *Entry) Tracef(template string, args ...any) {
+    this.log(logger.TraceLevel, func(p interfaces.Printer) {
+        p.Tracef(template, args...)
+    })
+}
+
+func (this *Entry) Debugf(template string, args ...any) {
+    this.log(logger.DebugLevel, func(p interfaces.Printer) {
+        p.Debugf(template, args...)
+    })
+}
+
+func (this *Entry) Infof(template string, args ...any) {
+    this.log(logger.InfoLevel, func(p interfaces.Printer) {
+        p.Infof(template, args...)
+    })
+}
+
+func (this *Entry) Warnf(template string, args ...any) {
+    this.log(logger.WarnLevel, func(p interfaces.Printer) {
+        p.Warnf(template, args...)
+    })
+}
+
+func (this *Entry) Warningf(template string, args ...any) {
+    this.log(logger.WarnLevel, func(p interfaces.Printer) {
+        p.Warningf(template, args...)
+    })
+}
+
+func (this *Entry) Errorf(template string, args ...any) {
+    this.log(logger.ErrorLevel, func(p interfaces.Printer) {
+        p.Errorf(template, args...)
+    })
+}
+
+func (this *Entry) Fatalf(template string, args ...any) {
+    this.fatal(func(p interfaces.Printer) {
+        p.Fatalf(template, args...)
+    }, func(p interfaces.Printer) {
+        p.Errorf(template, args...)
+    })
+}
+
+func (this *Entry) Panicf(template string, args ...any) {
+    this.panic(func(p interfaces.Printer) {
+        p.Panicf(template, args...)
+    }, func() string {
+        return fmt.Sprintf(template, args...)
+    })
+}
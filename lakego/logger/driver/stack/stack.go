@@ -0,0 +1,222 @@
+package stack
+
+import (
+    "sync"
+    "context"
+
+    "github.com/deatil/lakego-doak/lakego/logger"
+    "github.com/deatil/lakego-doak/lakego/logger/interfaces"
+)
+
+// 通道配置
+type ChannelConfig struct {
+    // 通道名称
+    Name string
+
+    // 最低记录等级
+    Level logger.Level
+}
+
+// 通道
+type Channel struct {
+    // 通道名称
+    Name string
+
+    // 最低记录等级
+    Level logger.Level
+
+    // 驱动
+    Driver interfaces.Driver
+}
+
+/**
+ * 多通道日志驱动
+ *
+ * 配置示例:
+ * stack:
+ *   type: stack
+ *   channels:
+ *     - daily
+ *     - name: stderr
+ *       level: warning
+ *     - name: error-file
+ *       level: error
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Stack struct {
+    // 锁
+    mu sync.RWMutex
+
+    // 通道列表
+    channels []Channel
+}
+
+// 构造方法
+func New() *Stack {
+    return &Stack{
+        channels: make([]Channel, 0),
+    }
+}
+
+// 添加通道
+func (this *Stack) WithChannel(name string, level logger.Level, driver interfaces.Driver) *Stack {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.channels = append(this.channels, Channel{
+        Name:   name,
+        Level:  level,
+        Driver: driver,
+    })
+
+    return this
+}
+
+// 获取通道列表
+func (this *Stack) GetChannels() []Channel {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    channels := make([]Channel, len(this.channels))
+    copy(channels, this.channels)
+
+    return channels
+}
+
+// 批量设置自定义变量
+func (this *Stack) WithFields(fields map[string]any) interfaces.Entry {
+    return this.entry().WithFields(fields)
+}
+
+// 设置自定义变量
+func (this *Stack) WithField(key string, value any) interfaces.Entry {
+    return this.entry().WithField(key, value)
+}
+
+// 设置错误信息
+func (this *Stack) WithError(err error) interfaces.Entry {
+    return this.entry().WithError(err)
+}
+
+// 设置上下文
+func (this *Stack) WithContext(ctx context.Context) interfaces.Entry {
+    return this.entry().WithContext(ctx)
+}
+
+// ========
+
+func (this *Stack) Trace(args ...any) {
+    this.entry().Trace(args...)
+}
+
+func (this *Stack) Debug(args ...any) {
+    this.entry().Debug(args...)
+}
+
+func (this *Stack) Info(args ...any) {
+    this.entry().Info(args...)
+}
+
+func (this *Stack) Warn(args ...any) {
+    this.entry().Warn(args...)
+}
+
+func (this *Stack) Warning(args ...any) {
+    this.entry().Warning(args...)
+}
+
+func (this *Stack) Error(args ...any) {
+    this.entry().Error(args...)
+}
+
+func (this *Stack) Fatal(args ...any) {
+    this.entry().Fatal(args...)
+}
+
+func (this *Stack) Panic(args ...any) {
+    this.entry().Panic(args...)
+}
+
+// ========
+
+func (this *Stack) Tracef(template string, args ...any) {
+    this.entry().Tracef(template, args...)
+}
+
+func (this *Stack) Debugf(template string, args ...any) {
+    this.entry().Debugf(template, args...)
+}
+
+func (this *Stack) Infof(template string, args ...any) {
+    this.entry().Infof(template, args...)
+}
+
+func (this *Stack) Warnf(template string, args ...any) {
+    this.entry().Warnf(template, args...)
+}
+
+func (this *Stack) Warningf(template string, args ...any) {
+    this.entry().Warningf(template, args...)
+}
+
+func (this *Stack) Errorf(template string, args ...any) {
+    this.entry().Errorf(template, args...)
+}
+
+func (this *Stack) Fatalf(template string, args ...any) {
+    this.entry().Fatalf(template, args...)
+}
+
+func (this *Stack) Panicf(template string, args ...any) {
+    this.entry().Panicf(template, args...)
+}
+
+// ========
+
+// 全部通道
+func (this *Stack) entry() *Entry {
+    channels := this.GetChannels()
+
+    targets := make([]target, 0, len(channels))
+    for _, channel := range channels {
+        targets = append(targets, target{
+            level: channel.Level,
+            entry: channel.Driver,
+        })
+    }
+
+    return &Entry{
+        targets: targets,
+    }
+}
+
+// 解析通道配置，通道可以为名称或者 {name, level} 数据
+func ParseChannels(conf map[string]any) []ChannelConfig {
+    channels := make([]ChannelConfig, 0)
+
+    items, _ := conf["channels"].([]any)
+    for _, item := range items {
+        switch v := item.(type) {
+            case string:
+                channels = append(channels, ChannelConfig{
+                    Name:  v,
+                    Level: logger.TraceLevel,
+                })
+
+            case map[string]any:
+                name, _ := v["name"].(string)
+                level, _ := v["level"].(string)
+
+                if name != "" {
+                    channels = append(channels, ChannelConfig{
+                        Name:  name,
+                        Level: logger.ParseLevel(level),
+                    })
+                }
+        }
+    }
+
+    return channels
+}
@@ -0,0 +1,147 @@
+package logger
+
+import (
+    "context"
+
+    "github.com/deatil/lakego-doak/lakego/logger/interfaces"
+)
+
+/**
+ * 带固定自定义数据的日志驱动
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type FieldsDriver struct {
+    // 日志驱动
+    driver interfaces.Driver
+
+    // 自定义数据，每次记录日志时获取
+    fields func() map[string]any
+}
+
+// 构造方法
+func NewFieldsDriver(driver interfaces.Driver, fields map[string]any) *FieldsDriver {
+    return NewFieldsDriverFunc(driver, func() map[string]any {
+        return fields
+    })
+}
+
+// 自定义数据在记录日志时才获取
+func NewFieldsDriverFunc(driver interfaces.Driver, fields func() map[string]any) *FieldsDriver {
+    return &FieldsDriver{
+        driver: driver,
+        fields: fields,
+    }
+}
+
+// 批量设置自定义变量
+func (this *FieldsDriver) WithFields(fields map[string]any) Entry {
+    return this.driver.WithFields(this.merge(fields))
+}
+
+// 设置自定义变量
+func (this *FieldsDriver) WithField(key string, value any) Entry {
+    return this.WithFields(map[string]any{
+        key: value,
+    })
+}
+
+// 设置错误信息
+func (this *FieldsDriver) WithError(err error) Entry {
+    return this.WithField(ErrorKey, err)
+}
+
+// 设置上下文
+func (this *FieldsDriver) WithContext(ctx context.Context) Entry {
+    return this.entry().WithContext(ctx)
+}
+
+// 合并自定义数据
+func (this *FieldsDriver) merge(fields map[string]any) map[string]any {
+    base := this.fields()
+
+    data := make(map[string]any, len(base) + len(fields))
+    for k, v := range base {
+        data[k] = v
+    }
+
+    for k, v := range fields {
+        data[k] = v
+    }
+
+    return data
+}
+
+// 带自定义数据的输出
+func (this *FieldsDriver) entry() Entry {
+    return this.driver.WithFields(this.merge(nil))
+}
+
+// ========
+
+func (this *FieldsDriver) Trace(args ...any) {
+    this.entry().Trace(args...)
+}
+
+func (this *FieldsDriver) Debug(args ...any) {
+    this.entry().Debug(args...)
+}
+
+func (this *FieldsDriver) Info(args ...any) {
+    this.entry().Info(args...)
+}
+
+func (this *FieldsDriver) Warn(args ...any) {
+    this.entry().Warn(args...)
+}
+
+func (this *FieldsDriver) Warning(args ...any) {
+    this.entry().Warning(args...)
+}
+
+func (this *FieldsDriver) Error(args ...any) {
+    this.entry().Error(args...)
+}
+
+func (this *FieldsDriver) Fatal(args ...any) {
+    this.entry().Fatal(args...)
+}
+
+func (this *FieldsDriver) Panic(args ...any) {
+    this.entry().Panic(args...)
+}
+
+// ========
+
+func (this *FieldsDriver) Tracef(template string, args ...any) {
+    this.entry().Tracef(template, args...)
+}
+
+func (this *FieldsDriver) Debugf(template string, args ...any) {
+    this.entry().Debugf(template, args...)
+}
+
+func (this *FieldsDriver) Infof(template string, args ...any) {
+    this.entry().Infof(template, args...)
+}
+
+func (this *FieldsDriver) Warnf(template string, args ...any) {
+    this.entry().Warnf(template, args...)
+}
+
+func (this *FieldsDriver) Warningf(template string, args ...any) {
+    this.entry().Warningf(template, args...)
+}
+
+func (this *FieldsDriver) Errorf(template string, args ...any) {
+    this.entry().Errorf(template, args...)
+}
+
+func (this *FieldsDriver) Fatalf(template string, args ...any) {
+    this.entry().Fatalf(template, args...)
+}
+
+func (this *FieldsDriver) Panicf(template string, args ...any) {
+    this.entry().Panicf(template, args...)
+}
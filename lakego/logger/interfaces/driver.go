@@ -1,5 +1,9 @@
 package interfaces
 
+import (
+    "context"
+)
+
 /**
  * 日志驱动接口
  *
@@ -8,44 +12,17 @@ package interfaces
  */
 type Driver interface {
     // 自定义数据
-    WithField(string, any) any
+    WithField(string, any) Entry
 
     // 自定义数据
-    WithFields(map[string]any) any
-
-    // ======
-
-    Trace(...any)
-
-    Debug(...any)
-
-    Info(...any)
-
-    Warn(...any)
-
-    Warning(...any)
-
-    Error(...any)
-
-    Fatal(...any)
-
-    Panic(...any)
-
-    // ======
-
-    Tracef(string, ...any)
-
-    Debugf(string, ...any)
-
-    Infof(string, ...any)
-
-    Warnf(string, ...any)
-
-    Warningf(string, ...any)
+    WithFields(map[string]any) Entry
 
-    Errorf(string, ...any)
+    // 错误信息
+    WithError(error) Entry
 
-    Fatalf(string, ...any)
+    // 上下文
+    WithContext(context.Context) Entry
 
-    Panicf(string, ...any)
+    // 输出
+    Printer
 }
@@ -0,0 +1,46 @@
+package interfaces
+
+/**
+ * 日志输出接口
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Printer interface {
+    Trace(...any)
+
+    Debug(...any)
+
+    Info(...any)
+
+    Warn(...any)
+
+    Warning(...any)
+
+    Error(...any)
+
+    Fatal(...any)
+
+    Panic(...any)
+
+    // ======
+
+    Tracef(string, ...any)
+
+    Debugf(string, ...any)
+
+    Infof(string, ...any)
+
+    Warnf(string, ...any)
+
+    Warningf(string, ...any)
+
+    Errorf(string, ...any)
+
+    Fatalf(string, ...any)
+
+    Panicf(string, ...any)
+}
+
+// 带自定义数据的日志，可链式调用，和驱动接口一致
+type Entry = Driver
@@ -0,0 +1,64 @@
+package logger
+
+import (
+    "strings"
+)
+
+// 日志等级
+type Level uint32
+
+// 等级从高到低
+const (
+    PanicLevel Level = iota
+    FatalLevel
+    ErrorLevel
+    WarnLevel
+    InfoLevel
+    DebugLevel
+    TraceLevel
+)
+
+// 等级名称
+var levelNames = map[Level]string{
+    PanicLevel: "panic",
+    FatalLevel: "fatal",
+    ErrorLevel: "error",
+    WarnLevel:  "warning",
+    InfoLevel:  "info",
+    DebugLevel: "debug",
+    TraceLevel: "trace",
+}
+
+// 解析等级，未知等级返回 TraceLevel
+func ParseLevel(level string) Level {
+    switch strings.ToLower(level) {
+        case "panic":
+            return PanicLevel
+        case "fatal":
+            return FatalLevel
+        case "error":
+            return ErrorLevel
+        case "warn", "warning":
+            return WarnLevel
+        case "info":
+            return InfoLevel
+        case "debug":
+            return DebugLevel
+    }
+
+    return TraceLevel
+}
+
+// 等级名称
+func (this Level) String() string {
+    if name, ok := levelNames[this]; ok {
+        return name
+    }
+
+    return "unknown"
+}
+
+// 当前等级是否需要记录 level 等级的日志
+func (this Level) Enabled(level Level) bool {
+    return level <= this
+}
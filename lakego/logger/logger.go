@@ -1,12 +1,20 @@
 package logger
 
 import (
+    "context"
+
     "github.com/deatil/lakego-doak/lakego/logger/interfaces"
 )
 
 // 变量
 type Fields map[string]any
 
+// 带自定义数据的日志
+type Entry = interfaces.Entry
+
+// 错误信息的字段名称
+const ErrorKey = "error"
+
 /**
  * 日志
  *
@@ -40,15 +48,27 @@ func (this *Logger) GetDriver() interfaces.Driver {
 }
 
 // 批量设置自定义变量
-func (this *Logger) WithFields(fields map[string]any) any {
+// logger.WithFields(map[string]any{"system": "lakego"}).Info("logger test")
+func (this *Logger) WithFields(fields map[string]any) Entry {
     return this.driver.WithFields(fields)
 }
 
 // 设置自定义变量
-func (this *Logger) WithField(key string, value any) any {
+// logger.WithField("system", "lakego").WithError(err).Error("logger test")
+func (this *Logger) WithField(key string, value any) Entry {
     return this.driver.WithField(key, value)
 }
 
+// 设置错误信息
+func (this *Logger) WithError(err error) Entry {
+    return this.driver.WithError(err)
+}
+
+// 设置上下文
+func (this *Logger) WithContext(ctx context.Context) Entry {
+    return this.driver.WithContext(ctx)
+}
+
 // ========
 
 func (this *Logger) Trace(args ...any) {
@@ -0,0 +1,510 @@
+package rotate
+
+import (
+    "os"
+    "io"
+    "fmt"
+    "sort"
+    "sync"
+    "time"
+    "strconv"
+    "strings"
+    "compress/gzip"
+    "path/filepath"
+)
+
+// 压缩文件后缀
+const compressSuffix = ".gz"
+
+// 备份文件时间格式
+const backupTimeFormat = "20060102T150405.000"
+
+// 备份文件时间格式的匹配规则
+const backupTimeGlob = "[0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9]T[0-9][0-9][0-9][0-9][0-9][0-9].[0-9][0-9][0-9]"
+
+// 配置
+type Options struct {
+    // 文件名称，支持 %Y %m %d %H %M %S 时间格式
+    // 例如: {runtime}/log/log_%Y%m%d.log
+    Filename string
+
+    // 单个文件最大字节数，0 为不限制
+    MaxSize int64
+
+    // 文件最大保存时间，0 为不限制
+    MaxAge time.Duration
+
+    // 最多保留的旧文件数量，0 为不限制
+    MaxBackups int
+
+    // 按时间切割的间隔，0 时只根据文件名称的时间格式切割
+    RotationTime time.Duration
+
+    // 是否 gzip 压缩切割后的文件
+    Compress bool
+
+    // 指向当前文件的软链接
+    LinkName string
+
+    // 当前时间，测试时使用
+    Now func() time.Time
+}
+
+/**
+ * 可切割的日志写入
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Writer struct {
+    // 锁
+    mu sync.Mutex
+
+    // 清理锁
+    millMu sync.Mutex
+
+    // 等待压缩及清理完成
+    millWg sync.WaitGroup
+
+    // 配置
+    opts Options
+
+    // 当前文件
+    file *os.File
+
+    // 当前文件名称
+    filename string
+
+    // 当前文件大小
+    size int64
+}
+
+// 构造函数
+func New(opts Options) *Writer {
+    if opts.Now == nil {
+        opts.Now = time.Now
+    }
+
+    return &Writer{
+        opts: opts,
+    }
+}
+
+// 写入
+func (this *Writer) Write(p []byte) (n int, err error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    name := this.currentName()
+    if this.file == nil || name != this.filename {
+        if err = this.openFile(name); err != nil {
+            return 0, err
+        }
+    }
+
+    writeLen := int64(len(p))
+    if this.opts.MaxSize > 0 && this.size > 0 && this.size + writeLen > this.opts.MaxSize {
+        if err = this.rotate(); err != nil {
+            return 0, err
+        }
+    }
+
+    n, err = this.file.Write(p)
+    this.size += int64(n)
+
+    return n, err
+}
+
+// 手动切割
+func (this *Writer) Rotate() error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if this.file == nil {
+        return nil
+    }
+
+    return this.rotate()
+}
+
+// 关闭，并等待旧文件处理完成
+func (this *Writer) Close() error {
+    this.mu.Lock()
+    err := this.closeFile()
+    this.mu.Unlock()
+
+    this.millWg.Wait()
+
+    return err
+}
+
+// 当前文件名称
+func (this *Writer) CurrentFileName() string {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if this.filename != "" {
+        return this.filename
+    }
+
+    return this.currentName()
+}
+
+// 根据时间生成的文件名称
+func (this *Writer) currentName() string {
+    now := this.opts.Now()
+
+    if this.opts.RotationTime > 0 {
+        now = truncateLocal(now, this.opts.RotationTime)
+    }
+
+    return FormatName(this.opts.Filename, now)
+}
+
+// 打开文件，已打开的文件会被关闭并处理
+func (this *Writer) openFile(name string) error {
+    if this.file != nil {
+        oldName := this.filename
+
+        if err := this.closeFile(); err != nil {
+            return err
+        }
+
+        this.startMill(oldName)
+    }
+
+    if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+        return fmt.Errorf("rotate: can't make directories for logfile: %s", err)
+    }
+
+    file, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+    if err != nil {
+        return fmt.Errorf("rotate: can't open logfile: %s", err)
+    }
+
+    info, err := file.Stat()
+    if err != nil {
+        file.Close()
+        return err
+    }
+
+    this.file = file
+    this.filename = name
+    this.size = info.Size()
+
+    this.link(name)
+
+    return nil
+}
+
+// 按大小切割当前文件
+func (this *Writer) rotate() error {
+    name := this.filename
+
+    if err := this.closeFile(); err != nil {
+        return err
+    }
+
+    backup := backupName(name, this.opts.Now())
+    if err := os.Rename(name, backup); err != nil {
+        return fmt.Errorf("rotate: can't rename logfile: %s", err)
+    }
+
+    this.startMill(backup)
+
+    return this.openFile(name)
+}
+
+// 关闭当前文件
+func (this *Writer) closeFile() error {
+    if this.file == nil {
+        return nil
+    }
+
+    err := this.file.Close()
+    this.file = nil
+    this.size = 0
+
+    return err
+}
+
+// 更新软链接
+func (this *Writer) link(name string) {
+    if this.opts.LinkName == "" {
+        return
+    }
+
+    target, err := filepath.Abs(name)
+    if err != nil {
+        return
+    }
+
+    tmp := this.opts.LinkName + "_symlink"
+    os.Remove(tmp)
+
+    if err := os.Symlink(target, tmp); err != nil {
+        return
+    }
+
+    os.Rename(tmp, this.opts.LinkName)
+}
+
+// 后台处理旧文件
+func (this *Writer) startMill(name string) {
+    this.millWg.Add(1)
+
+    go func() {
+        defer this.millWg.Done()
+
+        this.mill(name)
+    }()
+}
+
+// 压缩及清理旧文件
+func (this *Writer) mill(name string) {
+    this.millMu.Lock()
+    defer this.millMu.Unlock()
+
+    if this.opts.Compress && name != "" && !strings.HasSuffix(name, compressSuffix) {
+        compressFile(name)
+    }
+
+    this.cleanup()
+}
+
+// 清理超出数量及过期的旧文件
+func (this *Writer) cleanup() {
+    if this.opts.MaxBackups <= 0 && this.opts.MaxAge <= 0 {
+        return
+    }
+
+    this.mu.Lock()
+    current := this.filename
+    this.mu.Unlock()
+
+    files := this.oldFiles(current)
+
+    cutoff := this.opts.Now().Add(-this.opts.MaxAge)
+    for i, file := range files {
+        expired := this.opts.MaxAge > 0 && file.modTime.Before(cutoff)
+        overflow := this.opts.MaxBackups > 0 && i >= this.opts.MaxBackups
+
+        if expired || overflow {
+            os.Remove(file.path)
+        }
+    }
+}
+
+// 旧文件
+type oldFile struct {
+    path    string
+    modTime time.Time
+}
+
+// 旧文件列表，按时间倒序
+func (this *Writer) oldFiles(current string) []oldFile {
+    files := make([]oldFile, 0)
+    for _, file := range matchFiles(this.opts.Filename) {
+        if file.path != current {
+            files = append(files, file)
+        }
+    }
+
+    return files
+}
+
+// 匹配文件名称规则的文件，按时间倒序
+func matchFiles(filename string) []oldFile {
+    pattern := globPattern(filename)
+    ext := filepath.Ext(pattern)
+    base := strings.TrimSuffix(pattern, ext)
+
+    // 只匹配时间数字及备份时间格式，避免匹配到前缀相同的其他文件
+    globs := []string{
+        pattern,
+        pattern + compressSuffix,
+        base + "-" + backupTimeGlob + ext,
+        base + "-" + backupTimeGlob + ext + compressSuffix,
+        base + "-" + backupTimeGlob + ".*" + ext,
+        base + "-" + backupTimeGlob + ".*" + ext + compressSuffix,
+    }
+
+    seen := make(map[string]bool)
+    files := make([]oldFile, 0)
+
+    for i, glob := range globs {
+        matches, _ := filepath.Glob(glob)
+
+        for _, match := range matches {
+            if seen[match] {
+                continue
+            }
+
+            // 同一时间的备份序号只能为数字
+            if i >= 4 && !validBackupSeq(match) {
+                continue
+            }
+            seen[match] = true
+
+            info, err := os.Lstat(match)
+            if err != nil || !info.Mode().IsRegular() {
+                continue
+            }
+
+            files = append(files, oldFile{
+                path:    match,
+                modTime: info.ModTime(),
+            })
+        }
+    }
+
+    sort.Slice(files, func(i, j int) bool {
+        if files[i].modTime.Equal(files[j].modTime) {
+            stampI, seqI := backupOrder(files[i].path)
+            stampJ, seqJ := backupOrder(files[j].path)
+            if stampI != stampJ {
+                return stampI > stampJ
+            }
+
+            return seqI > seqJ
+        }
+
+        return files[i].modTime.After(files[j].modTime)
+    })
+
+    return files
+}
+
+// ==========
+
+// 文件名称规则对应的全部日志文件，包括切割及压缩的文件，按修改时间正序
+func Files(filename string) []string {
+    files := matchFiles(filename)
+
+    paths := make([]string, 0, len(files))
+    for i := len(files) - 1; i >= 0; i-- {
+        paths = append(paths, files[i].path)
+    }
+
+    return paths
+}
+
+// 格式化文件名称中的时间
+func FormatName(pattern string, t time.Time) string {
+    return strings.NewReplacer(
+        "%Y", t.Format("2006"),
+        "%m", t.Format("01"),
+        "%d", t.Format("02"),
+        "%H", t.Format("15"),
+        "%M", t.Format("04"),
+        "%S", t.Format("05"),
+        "%%", "%",
+    ).Replace(pattern)
+}
+
+// 文件名称匹配规则
+func globPattern(pattern string) string {
+    return strings.NewReplacer(
+        "%Y", "[0-9][0-9][0-9][0-9]",
+        "%m", "[0-9][0-9]",
+        "%d", "[0-9][0-9]",
+        "%H", "[0-9][0-9]",
+        "%M", "[0-9][0-9]",
+        "%S", "[0-9][0-9]",
+        "%%", "%",
+    ).Replace(pattern)
+}
+
+// 备份文件名称
+func backupName(name string, t time.Time) string {
+    ext := filepath.Ext(name)
+    base := strings.TrimSuffix(name, ext)
+
+    backup := fmt.Sprintf("%s-%s%s", base, t.Format(backupTimeFormat), ext)
+    for i := 1; fileExists(backup) || fileExists(backup + compressSuffix); i++ {
+        backup = fmt.Sprintf("%s-%s.%d%s", base, t.Format(backupTimeFormat), i, ext)
+    }
+
+    return backup
+}
+
+// 备份文件名称中的时间及序号，修改时间相同时用于排序
+func backupOrder(name string) (string, int) {
+    name = strings.TrimSuffix(name, compressSuffix)
+    name = strings.TrimSuffix(name, filepath.Ext(name))
+
+    stamp := name[strings.LastIndex(name, "-")+1:]
+
+    seq := 0
+    if len(stamp) > len(backupTimeFormat) + 1 {
+        seq, _ = strconv.Atoi(stamp[len(backupTimeFormat)+1:])
+        stamp = stamp[:len(backupTimeFormat)]
+    }
+
+    return stamp, seq
+}
+
+// 备份文件名称的序号是否为数字
+func validBackupSeq(name string) bool {
+    name = strings.TrimSuffix(name, compressSuffix)
+    name = strings.TrimSuffix(name, filepath.Ext(name))
+
+    stamp := name[strings.LastIndex(name, "-")+1:]
+    if len(stamp) <= len(backupTimeFormat) + 1 {
+        return false
+    }
+
+    _, err := strconv.ParseUint(stamp[len(backupTimeFormat)+1:], 10, 64)
+    return err == nil
+}
+
+// 按本地时区截断时间
+func truncateLocal(t time.Time, d time.Duration) time.Time {
+    _, offset := t.Zone()
+    shift := time.Duration(offset) * time.Second
+
+    return t.Add(shift).Truncate(d).Add(-shift)
+}
+
+// gzip 压缩文件，成功后删除原文件
+func compressFile(name string) error {
+    src, err := os.Open(name)
+    if err != nil {
+        return err
+    }
+    defer src.Close()
+
+    dst, err := os.OpenFile(name + compressSuffix, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+    if err != nil {
+        return err
+    }
+
+    gz := gzip.NewWriter(dst)
+    if _, err = io.Copy(gz, src); err == nil {
+        err = gz.Close()
+    }
+
+    if closeErr := dst.Close(); err == nil {
+        err = closeErr
+    }
+
+    if err != nil {
+        os.Remove(name + compressSuffix)
+        return err
+    }
+
+    // 保留原文件的修改时间
+    if info, err := src.Stat(); err == nil {
+        os.Chtimes(name + compressSuffix, info.ModTime(), info.ModTime())
+    }
+
+    src.Close()
+
+    return os.Remove(name)
+}
+
+// 文件是否存在
+func fileExists(name string) bool {
+    _, err := os.Stat(name)
+    return err == nil
+}
@@ -0,0 +1,234 @@
+package rotate
+
+import (
+    "os"
+    "io"
+    "time"
+    "sort"
+    "testing"
+    "reflect"
+    "compress/gzip"
+    "path/filepath"
+)
+
+func assertT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func Test_FormatName(t *testing.T) {
+    assert := assertT(t)
+
+    now := time.Date(2026, 10, 19, 8, 5, 3, 0, time.Local)
+
+    assert(FormatName("log_%Y%m%d.log", now), "log_20261019.log", "FormatName date")
+    assert(FormatName("log_%Y-%m-%d_%H%M%S.log", now), "log_2026-10-19_080503.log", "FormatName time")
+    assert(FormatName("log_100%%.log", now), "log_100%.log", "FormatName escape")
+    assert(globPattern("log_%Y%m%d.log"), "log_[0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9].log", "globPattern")
+}
+
+func Test_SizeRotate(t *testing.T) {
+    assert := assertT(t)
+
+    dir := t.TempDir()
+    now := time.Date(2026, 10, 19, 8, 0, 0, 0, time.Local)
+
+    writer := New(Options{
+        Filename:   filepath.Join(dir, "log_%Y%m%d.log"),
+        MaxSize:    10,
+        MaxBackups: 2,
+        Compress:   true,
+        LinkName:   filepath.Join(dir, "current.log"),
+        Now: func() time.Time {
+            now = now.Add(time.Second)
+            return now
+        },
+    })
+
+    for i := 0; i < 5; i++ {
+        _, err := writer.Write([]byte("0123456789"))
+        assert(err, nil, "Write")
+    }
+
+    assert(writer.Close(), nil, "Close")
+
+    current := filepath.Join(dir, "log_20261019.log")
+    data, _ := os.ReadFile(current)
+    assert(string(data), "0123456789", "current content")
+
+    backups, _ := filepath.Glob(filepath.Join(dir, "log_20261019-*.log.gz"))
+    assert(len(backups), 2, "backups count")
+
+    plain, _ := filepath.Glob(filepath.Join(dir, "log_20261019-*.log"))
+    assert(len(plain), 0, "uncompressed backups")
+
+    link, _ := os.Readlink(filepath.Join(dir, "current.log"))
+    assert(filepath.Base(link), "log_20261019.log", "link")
+}
+
+func Test_TimeRotate(t *testing.T) {
+    assert := assertT(t)
+
+    dir := t.TempDir()
+    now := time.Date(2026, 10, 19, 23, 0, 0, 0, time.Local)
+
+    writer := New(Options{
+        Filename: filepath.Join(dir, "log_%Y%m%d.log"),
+        Now: func() time.Time {
+            return now
+        },
+    })
+
+    writer.Write([]byte("first\n"))
+
+    now = now.Add(2 * time.Hour)
+    writer.Write([]byte("second\n"))
+
+    assert(writer.CurrentFileName(), filepath.Join(dir, "log_20261020.log"), "CurrentFileName")
+    assert(writer.Close(), nil, "Close")
+
+    first, _ := os.ReadFile(filepath.Join(dir, "log_20261019.log"))
+    assert(string(first), "first\n", "first file")
+
+    second, _ := os.ReadFile(filepath.Join(dir, "log_20261020.log"))
+    assert(string(second), "second\n", "second file")
+}
+
+func Test_SameSecondRotate(t *testing.T) {
+    assert := assertT(t)
+
+    dir := t.TempDir()
+    now := time.Date(2026, 10, 19, 8, 0, 0, 0, time.Local)
+
+    writer := New(Options{
+        Filename:   filepath.Join(dir, "log.log"),
+        MaxSize:    10,
+        MaxBackups: 2,
+        Now: func() time.Time {
+            return now
+        },
+    })
+
+    for _, data := range []string{"aaaaaaaaaa", "bbbbbbbbbb", "cccccccccc", "dddddddddd"} {
+        _, err := writer.Write([]byte(data))
+        assert(err, nil, "Write")
+    }
+
+    assert(writer.Close(), nil, "Close")
+
+    backups, _ := filepath.Glob(filepath.Join(dir, "log-*.log"))
+    sort.Strings(backups)
+
+    contents := make([]string, 0, len(backups))
+    for _, backup := range backups {
+        data, _ := os.ReadFile(backup)
+        contents = append(contents, string(data))
+    }
+
+    assert(contents, []string{"bbbbbbbbbb", "cccccccccc"}, "same second backups")
+
+    // 修改时间相同时按备份时间及序号排序
+    for _, backup := range backups {
+        os.Chtimes(backup, now, now)
+    }
+
+    files := writer.oldFiles(filepath.Join(dir, "log.log"))
+    assert(len(files), 2, "oldFiles count")
+    assert(filepath.Base(files[0].path), "log-20261019T080000.000.2.log", "oldFiles newest")
+    assert(filepath.Base(files[1].path), "log-20261019T080000.000.1.log", "oldFiles oldest")
+}
+
+func Test_CompressedOrder(t *testing.T) {
+    assert := assertT(t)
+
+    dir := t.TempDir()
+
+    name := filepath.Join(dir, "log-20261019T080000.000.log")
+    os.WriteFile(name, []byte("old"), 0644)
+
+    modTime := time.Date(2026, 10, 19, 8, 0, 0, 0, time.Local)
+    os.Chtimes(name, modTime, modTime)
+
+    assert(compressFile(name), nil, "compressFile")
+
+    info, err := os.Stat(name + compressSuffix)
+    assert(err, nil, "compressed file")
+    assert(info.ModTime().Equal(modTime), true, "compressed modTime")
+
+    // 压缩后的旧文件不会排到新文件之前
+    newer := filepath.Join(dir, "log-20261019T090000.000.log")
+    os.WriteFile(newer, []byte("new"), 0644)
+
+    files := matchFiles(filepath.Join(dir, "log.log"))
+    assert(len(files), 2, "matchFiles count")
+    assert(files[0].path, newer, "matchFiles newest")
+    assert(files[1].path, name + compressSuffix, "matchFiles compressed")
+
+    file, _ := os.Open(name + compressSuffix)
+    defer file.Close()
+
+    reader, err := gzip.NewReader(file)
+    assert(err, nil, "gzip reader")
+
+    data, _ := io.ReadAll(reader)
+    assert(string(data), "old", "compressed content")
+}
+
+func Test_MatchFilesSharedPrefix(t *testing.T) {
+    assert := assertT(t)
+
+    dir := t.TempDir()
+
+    names := []string{
+        "log_20261019.log",
+        "log_20261018.log.gz",
+        "log_20261019-20261019T080000.000.log",
+        "log_20261019-20261019T080000.000.2.log.gz",
+        "log_error_20261019.log",
+        "log_error_20261019-20261019T080000.000.log",
+        "log_2026101.log",
+        "log_20261019-20261019T080000.000.bak.log",
+        "app.log",
+        "app-20261019T080000.000.log",
+        "app-error.log",
+        "app-error-20261019T080000.000.log",
+    }
+    for _, name := range names {
+        os.WriteFile(filepath.Join(dir, name), []byte("log"), 0644)
+    }
+
+    matched := func(pattern string) []string {
+        files := make([]string, 0)
+        for _, file := range Files(filepath.Join(dir, pattern)) {
+            files = append(files, filepath.Base(file))
+        }
+
+        sort.Strings(files)
+        return files
+    }
+
+    assert(matched("log_%Y%m%d.log"), []string{
+        "log_20261018.log.gz",
+        "log_20261019-20261019T080000.000.2.log.gz",
+        "log_20261019-20261019T080000.000.log",
+        "log_20261019.log",
+    }, "Files date channel")
+
+    assert(matched("log_error_%Y%m%d.log"), []string{
+        "log_error_20261019-20261019T080000.000.log",
+        "log_error_20261019.log",
+    }, "Files error channel")
+
+    assert(matched("app.log"), []string{
+        "app-20261019T080000.000.log",
+        "app.log",
+    }, "Files app channel")
+
+    assert(matched("app-error.log"), []string{
+        "app-error-20261019T080000.000.log",
+        "app-error.log",
+    }, "Files app error channel")
+}
@@ -0,0 +1,130 @@
+package maintenance
+
+import (
+    "os"
+    "sync"
+    "time"
+    "encoding/json"
+    "path/filepath"
+
+    "github.com/deatil/lakego-doak/lakego/path"
+)
+
+// 维护标记文件
+const markerFile = "framework/down"
+
+var (
+    // 缓存锁
+    mu sync.Mutex
+
+    // 缓存的维护信息
+    cached *Payload
+
+    // 缓存的文件修改时间
+    cachedModTime time.Time
+)
+
+/**
+ * 维护信息
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Payload struct {
+    // 开始维护时间
+    Time int64 `json:"time"`
+
+    // 跳过维护的密钥，访问 /<secret> 后设置 cookie
+    Secret string `json:"secret,omitempty"`
+
+    // 重试时间，单位为秒
+    Retry int `json:"retry,omitempty"`
+
+    // 允许访问的 IP，支持 CIDR
+    Allow []string `json:"allow,omitempty"`
+
+    // 维护页面模板
+    Render string `json:"render,omitempty"`
+}
+
+// 标记文件路径
+func File() string {
+    return path.StoragePath(markerFile)
+}
+
+// 是否在维护中
+func IsDown() bool {
+    _, err := os.Stat(File())
+
+    return err == nil
+}
+
+// 获取维护信息，不在维护中时返回 nil
+// 文件没有变化时使用缓存
+func Get() (*Payload, error) {
+    info, err := os.Stat(File())
+    if err != nil {
+        if os.IsNotExist(err) {
+            return nil, nil
+        }
+
+        return nil, err
+    }
+
+    mu.Lock()
+    defer mu.Unlock()
+
+    if cached != nil && info.ModTime().Equal(cachedModTime) {
+        return cached, nil
+    }
+
+    data, err := os.ReadFile(File())
+    if err != nil {
+        if os.IsNotExist(err) {
+            return nil, nil
+        }
+
+        return nil, err
+    }
+
+    payload := &Payload{}
+    if len(data) > 0 {
+        if err := json.Unmarshal(data, payload); err != nil {
+            return nil, err
+        }
+    }
+
+    cached = payload
+    cachedModTime = info.ModTime()
+
+    return payload, nil
+}
+
+// 开始维护
+func Down(payload Payload) error {
+    if payload.Time == 0 {
+        payload.Time = time.Now().Unix()
+    }
+
+    data, err := json.MarshalIndent(payload, "", "    ")
+    if err != nil {
+        return err
+    }
+
+    file := File()
+    if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+        return err
+    }
+
+    return os.WriteFile(file, data, 0644)
+}
+
+// 结束维护
+func Up() error {
+    err := os.Remove(File())
+    if err != nil && !os.IsNotExist(err) {
+        return err
+    }
+
+    return nil
+}
@@ -0,0 +1,239 @@
+package accesslog
+
+import (
+    "fmt"
+    "time"
+    "strings"
+    "math/rand"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/logger"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+    facadeLogger "github.com/deatil/lakego-doak/lakego/facade/logger"
+    loggerMiddleware "github.com/deatil/lakego-doak/lakego/middleware/logger"
+)
+
+// 日志格式
+const (
+    FormatCommon   = "common"
+    FormatCombined = "combined"
+    FormatJSON     = "json"
+)
+
+// 时间格式
+const timeFormat = "02/Jan/2006:15:04:05 -0700"
+
+// 配置
+type Config struct {
+    // 日志通道，为空时使用默认日志
+    Channel string
+
+    // 日志格式 common | combined | json
+    Format string
+
+    // 采样率 0-1，错误及慢请求总会记录
+    SampleRate float64
+
+    // 不记录的路径，以 * 结尾时匹配前缀
+    SkipPaths []string
+
+    // 慢请求时间，0 为不标记
+    SlowThreshold time.Duration
+}
+
+/**
+ * 访问日志，配置读取 server.access-log
+ *
+ * access-log:
+ *   enable: true
+ *   channel: access
+ *   format: combined
+ *   sample-rate: 1
+ *   skip-paths:
+ *     - /health
+ *     - /static/*
+ *   slow-threshold: 1s
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Handler() router.HandlerFunc {
+    return HandlerWithConfig(ConfigFromServer())
+}
+
+// 服务配置中的访问日志配置
+func ConfigFromServer() Config {
+    conf := config.New("server")
+
+    return Config{
+        Channel:       conf.GetString("access-log.channel"),
+        Format:        conf.GetString("access-log.format"),
+        SampleRate:    conf.GetFloat64("access-log.sample-rate"),
+        SkipPaths:     conf.GetStringSlice("access-log.skip-paths"),
+        SlowThreshold: conf.GetDuration("access-log.slow-threshold"),
+    }
+}
+
+// 自定义配置
+func HandlerWithConfig(conf Config) router.HandlerFunc {
+    if conf.Format == "" {
+        conf.Format = FormatCombined
+    }
+
+    if conf.SampleRate <= 0 || conf.SampleRate > 1 {
+        conf.SampleRate = 1
+    }
+
+    return func(ctx *router.Context) {
+        path := ctx.Request.URL.Path
+        if skipPath(conf.SkipPaths, path) {
+            ctx.Next()
+            return
+        }
+
+        start := time.Now()
+
+        ctx.Next()
+
+        latency := time.Since(start)
+        status := ctx.Writer.Status()
+        slow := conf.SlowThreshold > 0 && latency >= conf.SlowThreshold
+
+        // 错误及慢请求不参与采样
+        if status < 500 && !slow && conf.SampleRate < 1 && rand.Float64() >= conf.SampleRate {
+            return
+        }
+
+        log := getLogger(conf.Channel)
+
+        var entry logger.Entry = log.GetDriver()
+        var message string
+
+        switch conf.Format {
+            case FormatJSON:
+                entry = log.WithFields(jsonFields(ctx, start, latency, slow))
+                message = "access"
+
+            case FormatCommon:
+                message = commonLine(ctx, start, latency, slow, false)
+
+            default:
+                message = commonLine(ctx, start, latency, slow, true)
+        }
+
+        switch {
+            case status >= 500:
+                entry.Error(message)
+
+            case status >= 400 || slow:
+                entry.Warn(message)
+
+            default:
+                entry.Info(message)
+        }
+    }
+}
+
+// 日志
+func getLogger(channel string) *logger.Logger {
+    if channel == "" {
+        return facadeLogger.Default
+    }
+
+    return facadeLogger.Channel(channel)
+}
+
+// 是否跳过
+func skipPath(paths []string, path string) bool {
+    for _, p := range paths {
+        if strings.HasSuffix(p, "*") {
+            if strings.HasPrefix(path, strings.TrimSuffix(p, "*")) {
+                return true
+            }
+        } else if p == path {
+            return true
+        }
+    }
+
+    return false
+}
+
+// 当前用户
+func userName(ctx *router.Context) string {
+    if user := loggerMiddleware.ResolveUser(ctx); user != nil {
+        return fmt.Sprintf("%v", user)
+    }
+
+    return "-"
+}
+
+// 响应大小
+func bodySize(ctx *router.Context) string {
+    if size := ctx.Writer.Size(); size > 0 {
+        return fmt.Sprintf("%d", size)
+    }
+
+    return "-"
+}
+
+// Common / Combined 格式
+// 127.0.0.1 - 1 [19/Oct/2026:12:00:00 +0800] "GET /admin HTTP/1.1" 200 512 "-" "curl/8.0"
+func commonLine(ctx *router.Context, start time.Time, latency time.Duration, slow bool, combined bool) string {
+    req := ctx.Request
+
+    line := fmt.Sprintf("%s - %s [%s] \"%s %s %s\" %d %s",
+        router.GetRequestIp(ctx),
+        userName(ctx),
+        start.Format(timeFormat),
+        req.Method,
+        req.RequestURI,
+        req.Proto,
+        ctx.Writer.Status(),
+        bodySize(ctx),
+    )
+
+    if combined {
+        referer := req.Referer()
+        if referer == "" {
+            referer = "-"
+        }
+
+        line += fmt.Sprintf(" \"%s\" \"%s\"", referer, req.UserAgent())
+    }
+
+    if slow {
+        line += fmt.Sprintf(" [slow %s]", latency)
+    }
+
+    return line
+}
+
+// JSON 格式数据
+func jsonFields(ctx *router.Context, start time.Time, latency time.Duration, slow bool) map[string]any {
+    req := ctx.Request
+
+    fields := map[string]any{
+        "request_id": loggerMiddleware.GetRequestID(ctx),
+        "ip":         router.GetRequestIp(ctx),
+        "method":     req.Method,
+        "path":       req.URL.Path,
+        "query":      req.URL.RawQuery,
+        "proto":      req.Proto,
+        "status":     ctx.Writer.Status(),
+        "size":       ctx.Writer.Size(),
+        "latency_ms": float64(latency.Microseconds()) / 1000,
+        "referer":    req.Referer(),
+        "user_agent": req.UserAgent(),
+        "start":      start.Format(time.RFC3339),
+    }
+
+    if user := loggerMiddleware.ResolveUser(ctx); user != nil {
+        fields["user"] = user
+    }
+
+    if slow {
+        fields["slow"] = true
+    }
+
+    return fields
+}
@@ -0,0 +1,184 @@
+package cors
+
+import (
+    "fmt"
+    "strings"
+    "net/http"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+    "github.com/deatil/lakego-doak/lakego/facade/logger"
+)
+
+// 默认允许的请求方式
+var defaultMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+
+// 配置
+type Config struct {
+    // 允许的来源，* 为全部，支持 https://*.example.com，允许携带凭证时不能使用 *
+    AllowOrigins []string
+
+    // 允许的请求方式
+    AllowMethods []string
+
+    // 允许的请求头，为空时使用预检请求的请求头
+    AllowHeaders []string
+
+    // 允许前端读取的响应头
+    ExposeHeaders []string
+
+    // 是否允许携带凭证
+    AllowCredentials bool
+
+    // 预检请求缓存时间，单位为秒
+    MaxAge int
+}
+
+/**
+ * 跨域请求，配置读取 server.cors
+ *
+ * 预检请求没有对应路由，需要作为全局中间件使用，设置 enable 为 true 时全局启用
+ *
+ * cors:
+ *   enable: true
+ *   allow-origins:
+ *     - https://*.example.com
+ *   allow-methods: [GET, POST, PUT, DELETE]
+ *   allow-headers: [Authorization, Content-Type]
+ *   expose-headers: [X-Request-ID]
+ *   allow-credentials: true
+ *   max-age: 600
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Handler() router.HandlerFunc {
+    return HandlerWithConfig(ConfigFromServer())
+}
+
+// 服务配置中的跨域配置
+func ConfigFromServer() Config {
+    conf := config.New("server")
+
+    return Config{
+        AllowOrigins:     conf.GetStringSlice("cors.allow-origins"),
+        AllowMethods:     conf.GetStringSlice("cors.allow-methods"),
+        AllowHeaders:     conf.GetStringSlice("cors.allow-headers"),
+        ExposeHeaders:    conf.GetStringSlice("cors.expose-headers"),
+        AllowCredentials: conf.GetBool("cors.allow-credentials"),
+        MaxAge:           conf.GetInt("cors.max-age"),
+    }
+}
+
+// 自定义配置
+func HandlerWithConfig(conf Config) router.HandlerFunc {
+    if len(conf.AllowMethods) == 0 {
+        conf.AllowMethods = defaultMethods
+    }
+
+    // 允许全部来源时携带凭证会让任意网站读取需要登录的数据
+    if conf.AllowCredentials && contains(conf.AllowOrigins, "*") {
+        logger.Default.Errorf("cors: allow-origins [*] can not be used with allow-credentials, credentials are ignored")
+
+        conf.AllowCredentials = false
+    }
+
+    allowMethods := strings.ToUpper(strings.Join(conf.AllowMethods, ", "))
+    allowHeaders := strings.Join(conf.AllowHeaders, ", ")
+    exposeHeaders := strings.Join(conf.ExposeHeaders, ", ")
+
+    return func(ctx *router.Context) {
+        origin := ctx.GetHeader("Origin")
+        if origin == "" {
+            ctx.Next()
+            return
+        }
+
+        header := ctx.Writer.Header()
+        header.Add("Vary", "Origin")
+
+        preflight := ctx.Request.Method == http.MethodOptions &&
+            ctx.GetHeader("Access-Control-Request-Method") != ""
+
+        if !allowOrigin(conf.AllowOrigins, origin) {
+            if preflight {
+                ctx.AbortWithStatus(http.StatusForbidden)
+                return
+            }
+
+            ctx.Next()
+            return
+        }
+
+        if contains(conf.AllowOrigins, "*") {
+            header.Set("Access-Control-Allow-Origin", "*")
+        } else {
+            header.Set("Access-Control-Allow-Origin", origin)
+        }
+
+        if conf.AllowCredentials {
+            header.Set("Access-Control-Allow-Credentials", "true")
+        }
+
+        if !preflight {
+            if exposeHeaders != "" {
+                header.Set("Access-Control-Expose-Headers", exposeHeaders)
+            }
+
+            ctx.Next()
+            return
+        }
+
+        header.Add("Vary", "Access-Control-Request-Method")
+        header.Add("Vary", "Access-Control-Request-Headers")
+
+        header.Set("Access-Control-Allow-Methods", allowMethods)
+
+        if allowHeaders != "" {
+            header.Set("Access-Control-Allow-Headers", allowHeaders)
+        } else if requestHeaders := ctx.GetHeader("Access-Control-Request-Headers"); requestHeaders != "" {
+            header.Set("Access-Control-Allow-Headers", requestHeaders)
+        }
+
+        if conf.MaxAge > 0 {
+            header.Set("Access-Control-Max-Age", fmt.Sprintf("%d", conf.MaxAge))
+        }
+
+        ctx.AbortWithStatus(http.StatusNoContent)
+    }
+}
+
+// 来源是否允许
+func allowOrigin(origins []string, origin string) bool {
+    for _, allow := range origins {
+        if allow == "*" || strings.EqualFold(allow, origin) {
+            return true
+        }
+
+        // https://*.example.com
+        if index := strings.Index(allow, "*"); index != -1 {
+            prefix := strings.ToLower(allow[:index])
+            suffix := strings.ToLower(allow[index + 1:])
+            lower := strings.ToLower(origin)
+
+            if len(lower) > len(prefix) + len(suffix) &&
+                strings.HasPrefix(lower, prefix) &&
+                strings.HasSuffix(lower, suffix) {
+                return true
+            }
+        }
+    }
+
+    return false
+}
+
+// 是否包含
+func contains(list []string, value string) bool {
+    for _, item := range list {
+        if item == value {
+            return true
+        }
+    }
+
+    return false
+}
@@ -0,0 +1,77 @@
+package cors
+
+import (
+    "testing"
+    "reflect"
+    "net/http"
+    "net/http/httptest"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+func assertT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func Test_AllowOrigin(t *testing.T) {
+    assert := assertT(t)
+
+    origins := []string{"https://example.com", "https://*.example.org", "http://localhost:*"}
+
+    assert(allowOrigin(origins, "https://example.com"), true, "allowOrigin exact")
+    assert(allowOrigin(origins, "HTTPS://Example.com"), true, "allowOrigin case")
+    assert(allowOrigin(origins, "https://example.com.evil.com"), false, "allowOrigin exact suffix")
+    assert(allowOrigin(origins, "https://api.example.org"), true, "allowOrigin wildcard")
+    assert(allowOrigin(origins, "https://a.b.example.org"), true, "allowOrigin wildcard nested")
+    assert(allowOrigin(origins, "https://.example.org"), false, "allowOrigin wildcard empty label")
+    assert(allowOrigin(origins, "https://example.org"), false, "allowOrigin wildcard bare domain")
+    assert(allowOrigin(origins, "https://evilexample.org"), false, "allowOrigin wildcard without dot")
+    assert(allowOrigin(origins, "http://api.example.org"), false, "allowOrigin wildcard scheme")
+    assert(allowOrigin(origins, "http://localhost:8080"), true, "allowOrigin wildcard port")
+    assert(allowOrigin(origins, "null"), false, "allowOrigin null")
+
+    assert(allowOrigin([]string{"*"}, "https://any.com"), true, "allowOrigin all")
+    assert(allowOrigin(nil, "https://example.com"), false, "allowOrigin empty")
+}
+
+func Test_HandlerCredentials(t *testing.T) {
+    assert := assertT(t)
+
+    request := func(conf Config, origin string) http.Header {
+        engine := router.New()
+        engine.Use(HandlerWithConfig(conf))
+        engine.GET("/ping", func(ctx *router.Context) {})
+
+        req := httptest.NewRequest("GET", "/ping", nil)
+        req.Header.Set("Origin", origin)
+
+        w := httptest.NewRecorder()
+        engine.ServeHTTP(w, req)
+
+        return w.Header()
+    }
+
+    header := request(Config{
+        AllowOrigins:     []string{"https://example.com"},
+        AllowCredentials: true,
+    }, "https://example.com")
+    assert(header.Get("Access-Control-Allow-Origin"), "https://example.com", "credentials origin")
+    assert(header.Get("Access-Control-Allow-Credentials"), "true", "credentials")
+
+    // 允许全部来源时忽略凭证
+    header = request(Config{
+        AllowOrigins:     []string{"*"},
+        AllowCredentials: true,
+    }, "https://evil.com")
+    assert(header.Get("Access-Control-Allow-Origin"), "*", "wildcard credentials origin")
+    assert(header.Get("Access-Control-Allow-Credentials"), "", "wildcard credentials ignored")
+
+    header = request(Config{
+        AllowOrigins: []string{"https://example.com"},
+    }, "https://evil.com")
+    assert(header.Get("Access-Control-Allow-Origin"), "", "origin not allowed")
+}
@@ -0,0 +1,126 @@
+package logger
+
+import (
+    "sync"
+    "strings"
+
+    "github.com/deatil/lakego-doak/lakego/uuid"
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/logger"
+    facadeLogger "github.com/deatil/lakego-doak/lakego/facade/logger"
+)
+
+// 请求 ID 在上下文中的键名
+const RequestIDKey = "request_id"
+
+// 请求 ID 头信息
+const RequestIDHeader = "X-Request-ID"
+
+// 请求 ID 最大长度，超出时重新生成
+const requestIDMaxLength = 128
+
+// 获取当前用户
+type UserResolver = func(*router.Context) any
+
+var (
+    // 锁
+    mu sync.RWMutex
+
+    // 默认从上下文的 user_id 获取用户
+    userResolver UserResolver = func(ctx *router.Context) any {
+        if user, ok := ctx.Get("user_id"); ok {
+            return user
+        }
+
+        return nil
+    }
+)
+
+// 设置获取当前用户的方法
+func SetUserResolver(resolver UserResolver) {
+    mu.Lock()
+    defer mu.Unlock()
+
+    userResolver = resolver
+}
+
+/**
+ * 请求上下文日志
+ *
+ * 日志会附带请求 ID、路由别名、IP 及当前用户，
+ * 使用 logger.FromContext(ctx) 获取
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Handler() router.HandlerFunc {
+    return func(ctx *router.Context) {
+        requestID := GetRequestID(ctx)
+
+        fields := map[string]any{
+            "request_id": requestID,
+            "route":      router.DefaultName().GetNameByRoute(ctx.Request.Method, ctx.FullPath()),
+            "ip":         router.GetRequestIp(ctx),
+        }
+
+        // 用户在认证后才存在，所以在记录日志时获取
+        driver := logger.NewFieldsDriverFunc(facadeLogger.Default.GetDriver(), func() map[string]any {
+            data := make(map[string]any, len(fields) + 1)
+            for k, v := range fields {
+                data[k] = v
+            }
+
+            if user := ResolveUser(ctx); user != nil {
+                data["user"] = user
+            }
+
+            return data
+        })
+
+        ctx.Set(logger.ContextKey, driver)
+        ctx.Request = ctx.Request.WithContext(logger.NewContext(ctx.Request.Context(), driver))
+
+        ctx.Next()
+    }
+}
+
+// 获取请求 ID，不存在时生成
+func GetRequestID(ctx *router.Context) string {
+    if requestID := ctx.GetString(RequestIDKey); requestID != "" {
+        return requestID
+    }
+
+    // 请求头的值不可信，格式错误时重新生成
+    requestID := ctx.GetHeader(RequestIDHeader)
+    if !validRequestID(requestID) {
+        requestID = uuid.ToUUIDString()
+    }
+
+    ctx.Set(RequestIDKey, requestID)
+
+    return requestID
+}
+
+// 当前用户
+func ResolveUser(ctx *router.Context) any {
+    mu.RLock()
+    resolver := userResolver
+    mu.RUnlock()
+
+    if resolver == nil {
+        return nil
+    }
+
+    return resolver(ctx)
+}
+
+// 请求 ID 只能包含可见字符
+func validRequestID(requestID string) bool {
+    if requestID == "" || len(requestID) > requestIDMaxLength {
+        return false
+    }
+
+    return strings.IndexFunc(requestID, func(r rune) bool {
+        return r < 0x21 || r > 0x7e
+    }) == -1
+}
@@ -0,0 +1,151 @@
+package maintenance
+
+import (
+    "fmt"
+    "net"
+    "time"
+    "strings"
+    "strconv"
+    "net/http"
+    "crypto/hmac"
+    "crypto/sha256"
+    "encoding/hex"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/maintenance"
+    "github.com/deatil/lakego-doak/lakego/facade/logger"
+)
+
+// 跳过维护的 cookie 名称
+const CookieName = "lakego_maintenance"
+
+// 跳过维护的 cookie 有效时间
+const cookieExpire = 12 * time.Hour
+
+/**
+ * 维护模式，维护中时返回 503
+ *
+ * 允许的 IP 及带有跳过 cookie 的请求可以正常访问，
+ * 配置 server.trusted-proxies 后 IP 只在请求来自这些代理时才使用 X-Forwarded-For 获取，
+ * 没有配置时信任全部代理，允许的 IP 可以被伪造，
+ * 访问 /<secret> 后设置跳过 cookie 并跳转到首页
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Handler() router.HandlerFunc {
+    return func(ctx *router.Context) {
+        payload, err := maintenance.Get()
+        if err != nil {
+            logger.Default.Errorf("maintenance: read marker file failed: %s", err.Error())
+        }
+
+        if payload == nil {
+            ctx.Next()
+            return
+        }
+
+        // 使用密钥获取跳过 cookie
+        if payload.Secret != "" && ctx.Request.URL.Path == "/" + payload.Secret {
+            expires := time.Now().Add(cookieExpire)
+
+            ctx.SetSameSite(http.SameSiteLaxMode)
+            ctx.SetCookie(CookieName, cookieValue(payload.Secret, expires), int(cookieExpire.Seconds()), "/", "", false, true)
+            ctx.Redirect(http.StatusFound, "/")
+            ctx.Abort()
+            return
+        }
+
+        if hasValidCookie(ctx, payload.Secret) || allowIP(payload.Allow, router.GetRequestIp(ctx)) {
+            ctx.Next()
+            return
+        }
+
+        if payload.Retry > 0 {
+            ctx.Header("Retry-After", strconv.Itoa(payload.Retry))
+        }
+
+        if payload.Render != "" {
+            ctx.HTML(http.StatusServiceUnavailable, payload.Render, router.H{
+                "retry": payload.Retry,
+                "time":  payload.Time,
+            })
+            ctx.Abort()
+            return
+        }
+
+        ctx.AbortWithStatusJSON(http.StatusServiceUnavailable, router.H{
+            "code":    http.StatusServiceUnavailable,
+            "message": "Service Unavailable",
+        })
+    }
+}
+
+// 跳过 cookie 的值，过期时间及签名
+func cookieValue(secret string, expires time.Time) string {
+    expiresAt := fmt.Sprintf("%d", expires.Unix())
+
+    return expiresAt + "." + sign(secret, expiresAt)
+}
+
+// 签名
+func sign(secret string, data string) string {
+    mac := hmac.New(sha256.New, []byte(secret))
+    mac.Write([]byte(data))
+
+    return hex.EncodeToString(mac.Sum(nil))
+}
+
+// 是否有未过期的跳过 cookie，密钥变化后原 cookie 失效
+func hasValidCookie(ctx *router.Context, secret string) bool {
+    if secret == "" {
+        return false
+    }
+
+    value, err := ctx.Cookie(CookieName)
+    if err != nil {
+        return false
+    }
+
+    parts := strings.SplitN(value, ".", 2)
+    if len(parts) != 2 {
+        return false
+    }
+
+    if !hmac.Equal([]byte(parts[1]), []byte(sign(secret, parts[0]))) {
+        return false
+    }
+
+    expires, err := strconv.ParseInt(parts[0], 10, 64)
+    if err != nil {
+        return false
+    }
+
+    return time.Now().Unix() < expires
+}
+
+// IP 是否允许访问
+func allowIP(allow []string, ip string) bool {
+    requestIP := net.ParseIP(ip)
+    if requestIP == nil {
+        return false
+    }
+
+    for _, item := range allow {
+        item = strings.TrimSpace(item)
+
+        if strings.Contains(item, "/") {
+            if _, network, err := net.ParseCIDR(item); err == nil && network.Contains(requestIP) {
+                return true
+            }
+
+            continue
+        }
+
+        if allowed := net.ParseIP(item); allowed != nil && allowed.Equal(requestIP) {
+            return true
+        }
+    }
+
+    return false
+}
@@ -0,0 +1,70 @@
+package maintenance
+
+import (
+    "time"
+    "strings"
+    "testing"
+    "reflect"
+    "net/http"
+    "net/http/httptest"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+func assertT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+// 带有跳过 cookie 的请求
+func cookieContext(value string) *router.Context {
+    req := httptest.NewRequest("GET", "/", nil)
+    if value != "" {
+        req.AddCookie(&http.Cookie{Name: CookieName, Value: value})
+    }
+
+    return &router.Context{Request: req}
+}
+
+func Test_HasValidCookie(t *testing.T) {
+    assert := assertT(t)
+
+    secret := "deploy-secret"
+    valid := cookieValue(secret, time.Now().Add(time.Hour))
+
+    assert(hasValidCookie(cookieContext(valid), secret), true, "hasValidCookie")
+    assert(hasValidCookie(cookieContext(valid), ""), false, "hasValidCookie without secret")
+    assert(hasValidCookie(cookieContext(valid), "other-secret"), false, "hasValidCookie secret changed")
+    assert(hasValidCookie(cookieContext(""), secret), false, "hasValidCookie missing")
+    assert(hasValidCookie(cookieContext("no-signature"), secret), false, "hasValidCookie format")
+
+    expired := cookieValue(secret, time.Now().Add(-time.Second))
+    assert(hasValidCookie(cookieContext(expired), secret), false, "hasValidCookie expired")
+
+    // 修改过期时间后签名不匹配
+    tampered := "9999999999" + expired[strings.Index(expired, "."):]
+    assert(hasValidCookie(cookieContext(tampered), secret), false, "hasValidCookie tampered expires")
+
+    forged := "9999999999." + sign("guess", "9999999999")
+    assert(hasValidCookie(cookieContext(forged), secret), false, "hasValidCookie tampered signature")
+    assert(hasValidCookie(cookieContext("never." + sign(secret, "never")), secret), false, "hasValidCookie bad expires")
+}
+
+func Test_AllowIP(t *testing.T) {
+    assert := assertT(t)
+
+    allow := []string{"127.0.0.1", " 10.0.0.0/8 ", "2001:db8::/32", "bad", "192.168.1.300"}
+
+    assert(allowIP(allow, "127.0.0.1"), true, "allowIP exact")
+    assert(allowIP(allow, "127.0.0.2"), false, "allowIP exact other")
+    assert(allowIP(allow, "10.20.30.40"), true, "allowIP cidr")
+    assert(allowIP(allow, "11.0.0.1"), false, "allowIP cidr other")
+    assert(allowIP(allow, "2001:db8::1"), true, "allowIP ipv6 cidr")
+    assert(allowIP(allow, "::ffff:127.0.0.1"), true, "allowIP ipv4 mapped")
+    assert(allowIP(allow, ""), false, "allowIP empty")
+    assert(allowIP(allow, "bad"), false, "allowIP invalid")
+    assert(allowIP(nil, "127.0.0.1"), false, "allowIP no allow list")
+}
@@ -14,6 +14,7 @@ import (
 
     "github.com/deatil/lakego-doak/lakego/router"
     "github.com/deatil/lakego-doak/lakego/facade"
+    "github.com/deatil/lakego-doak/lakego/facade/logger"
 )
 
 var (
@@ -97,8 +98,8 @@ func Handler() router.HandlerFunc {
                     responsedata = router.H{}
                 }
 
-                // 记录日志
-                facade.Logger.Error(logData)
+                // 记录日志，带请求上下文信息
+                logger.FromContext(ctx).Error(logData)
 
                 if brokenPipe {
                     responseData(ctx, "服务器内部异常", responsedata)
@@ -0,0 +1,50 @@
+package requestid
+
+import (
+    "context"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+    loggerMiddleware "github.com/deatil/lakego-doak/lakego/middleware/logger"
+)
+
+// 请求 ID 在 context 中的键
+type contextKey struct{}
+
+/**
+ * 请求 ID
+ *
+ * 使用请求头 X-Request-ID 或者生成新的 ID，格式错误时重新生成，保存到上下文及请求的 context 中，
+ * 并在响应头中返回，使用 requestid.Get(ctx) 或者 requestid.FromContext(ctx) 获取
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Handler() router.HandlerFunc {
+    return func(ctx *router.Context) {
+        requestID := loggerMiddleware.GetRequestID(ctx)
+
+        ctx.Request = ctx.Request.WithContext(NewContext(ctx.Request.Context(), requestID))
+        ctx.Header(loggerMiddleware.RequestIDHeader, requestID)
+
+        ctx.Next()
+    }
+}
+
+// 获取请求 ID
+func Get(ctx *router.Context) string {
+    return loggerMiddleware.GetRequestID(ctx)
+}
+
+// 保存请求 ID 到 context
+func NewContext(ctx context.Context, requestID string) context.Context {
+    return context.WithValue(ctx, contextKey{}, requestID)
+}
+
+// 从 context 获取请求 ID，不存在时为空
+func FromContext(ctx context.Context) string {
+    if requestID, ok := ctx.Value(contextKey{}).(string); ok {
+        return requestID
+    }
+
+    return ""
+}
@@ -0,0 +1,148 @@
+package secure
+
+import (
+    "fmt"
+    "strings"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+)
+
+// 配置
+type Config struct {
+    // HSTS 时间，单位为秒，0 为不设置，只在 https 请求时设置
+    HSTSMaxAge int
+
+    // HSTS 包含子域名
+    HSTSIncludeSubdomains bool
+
+    // HSTS 预加载
+    HSTSPreload bool
+
+    // 内容安全策略
+    ContentSecurityPolicy string
+
+    // 只报告的内容安全策略
+    ContentSecurityPolicyReportOnly bool
+
+    // 页面嵌入 DENY | SAMEORIGIN，为空时不设置
+    FrameOptions string
+
+    // 禁止猜测内容类型
+    ContentTypeNosniff bool
+
+    // 来源策略
+    ReferrerPolicy string
+}
+
+// 默认配置
+func DefaultConfig() Config {
+    return Config{
+        FrameOptions:       "SAMEORIGIN",
+        ContentTypeNosniff: true,
+        ReferrerPolicy:     "strict-origin-when-cross-origin",
+    }
+}
+
+/**
+ * 安全响应头，配置读取 server.secure，没有配置的项使用默认配置
+ *
+ * secure:
+ *   hsts-max-age: 31536000
+ *   hsts-include-subdomains: true
+ *   hsts-preload: false
+ *   content-security-policy: "default-src 'self'"
+ *   content-security-policy-report-only: false
+ *   frame-options: DENY
+ *   content-type-nosniff: true
+ *   referrer-policy: no-referrer
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Handler() router.HandlerFunc {
+    return HandlerWithConfig(ConfigFromServer())
+}
+
+// 服务配置中的安全响应头配置
+func ConfigFromServer() Config {
+    conf := config.New("server")
+
+    cfg := DefaultConfig()
+
+    cfg.HSTSMaxAge = conf.GetInt("secure.hsts-max-age")
+    cfg.HSTSIncludeSubdomains = conf.GetBool("secure.hsts-include-subdomains")
+    cfg.HSTSPreload = conf.GetBool("secure.hsts-preload")
+    cfg.ContentSecurityPolicy = conf.GetString("secure.content-security-policy")
+    cfg.ContentSecurityPolicyReportOnly = conf.GetBool("secure.content-security-policy-report-only")
+
+    if conf.IsSet("secure.frame-options") {
+        cfg.FrameOptions = conf.GetString("secure.frame-options")
+    }
+
+    if conf.IsSet("secure.content-type-nosniff") {
+        cfg.ContentTypeNosniff = conf.GetBool("secure.content-type-nosniff")
+    }
+
+    if conf.IsSet("secure.referrer-policy") {
+        cfg.ReferrerPolicy = conf.GetString("secure.referrer-policy")
+    }
+
+    return cfg
+}
+
+// 自定义配置
+func HandlerWithConfig(conf Config) router.HandlerFunc {
+    hsts := ""
+    if conf.HSTSMaxAge > 0 {
+        hsts = fmt.Sprintf("max-age=%d", conf.HSTSMaxAge)
+
+        if conf.HSTSIncludeSubdomains {
+            hsts += "; includeSubDomains"
+        }
+
+        if conf.HSTSPreload {
+            hsts += "; preload"
+        }
+    }
+
+    cspHeader := "Content-Security-Policy"
+    if conf.ContentSecurityPolicyReportOnly {
+        cspHeader = "Content-Security-Policy-Report-Only"
+    }
+
+    return func(ctx *router.Context) {
+        header := ctx.Writer.Header()
+
+        if hsts != "" && isHTTPS(ctx) {
+            header.Set("Strict-Transport-Security", hsts)
+        }
+
+        if conf.ContentSecurityPolicy != "" {
+            header.Set(cspHeader, conf.ContentSecurityPolicy)
+        }
+
+        if conf.FrameOptions != "" {
+            header.Set("X-Frame-Options", strings.ToUpper(conf.FrameOptions))
+        }
+
+        if conf.ContentTypeNosniff {
+            header.Set("X-Content-Type-Options", "nosniff")
+        }
+
+        if conf.ReferrerPolicy != "" {
+            header.Set("Referrer-Policy", conf.ReferrerPolicy)
+        }
+
+        ctx.Next()
+    }
+}
+
+// 是否为 https 请求，包括代理转发的请求
+func isHTTPS(ctx *router.Context) bool {
+    if ctx.Request.TLS != nil {
+        return true
+    }
+
+    return strings.EqualFold(ctx.GetHeader("X-Forwarded-Proto"), "https")
+}
@@ -0,0 +1,174 @@
+package throttle
+
+import (
+    "fmt"
+    "time"
+    "strings"
+    "strconv"
+
+    "github.com/deatil/lakego-doak/lakego/router"
+    loggerMiddleware "github.com/deatil/lakego-doak/lakego/middleware/logger"
+)
+
+// 限流依据
+const (
+    ByIP    = "ip"
+    ByUser  = "user"
+    ByRoute = "route"
+)
+
+// 限流算法
+const (
+    FixedWindow   = "fixed"
+    SlidingWindow = "sliding"
+)
+
+// 时间单位
+var rateUnits = map[string]time.Duration{
+    "second": time.Second,
+    "minute": time.Minute,
+    "hour":   time.Hour,
+    "day":    24 * time.Hour,
+
+    "s": time.Second,
+    "m": time.Minute,
+    "h": time.Hour,
+    "d": 24 * time.Hour,
+}
+
+/**
+ * 限流器
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Limiter struct {
+    // 时间内最多请求次数
+    MaxAttempts int
+
+    // 时间
+    Decay time.Duration
+
+    // 限流依据 ip | user | route，多个使用逗号分隔
+    By string
+
+    // 限流算法 fixed | sliding
+    Algorithm string
+
+    // 自定义限流依据，设置后 By 无效
+    KeyFunc func(*router.Context) string
+
+    // 自定义超出限制的响应
+    Response func(*router.Context, Result)
+}
+
+// 使用频率创建限流器，比如 60/minute
+func NewLimiter(rate string) (*Limiter, error) {
+    max, decay, err := ParseRate(rate)
+    if err != nil {
+        return nil, err
+    }
+
+    return &Limiter{
+        MaxAttempts: max,
+        Decay:       decay,
+        By:          ByIP,
+        Algorithm:   FixedWindow,
+    }, nil
+}
+
+// 限流依据
+func (this *Limiter) WithBy(by string) *Limiter {
+    this.By = by
+
+    return this
+}
+
+// 限流算法
+func (this *Limiter) WithAlgorithm(algorithm string) *Limiter {
+    this.Algorithm = algorithm
+
+    return this
+}
+
+// 自定义限流依据
+func (this *Limiter) WithKeyFunc(fn func(*router.Context) string) *Limiter {
+    this.KeyFunc = fn
+
+    return this
+}
+
+// 自定义超出限制的响应
+func (this *Limiter) WithResponse(fn func(*router.Context, Result)) *Limiter {
+    this.Response = fn
+
+    return this
+}
+
+// 请求的限流标识
+func (this *Limiter) Key(ctx *router.Context) string {
+    if this.KeyFunc != nil {
+        return this.KeyFunc(ctx)
+    }
+
+    by := this.By
+    if by == "" {
+        by = ByIP
+    }
+
+    parts := make([]string, 0)
+    for _, item := range strings.Split(by, ",") {
+        switch strings.TrimSpace(item) {
+            case ByUser:
+                // 未登录时使用 IP
+                if user := loggerMiddleware.ResolveUser(ctx); user != nil {
+                    parts = append(parts, fmt.Sprintf("user:%v", user))
+                } else {
+                    parts = append(parts, "ip:" + router.GetRequestIp(ctx))
+                }
+
+            case ByRoute:
+                path := ctx.FullPath()
+                if path == "" {
+                    path = ctx.Request.URL.Path
+                }
+
+                parts = append(parts, "route:" + ctx.Request.Method + ":" + path)
+
+            default:
+                parts = append(parts, "ip:" + router.GetRequestIp(ctx))
+        }
+    }
+
+    return strings.Join(parts, "|")
+}
+
+// 解析频率，支持 60/minute, 10/second, 1000/hour, 5/day, 10/s 及 100/10m
+func ParseRate(rate string) (int, time.Duration, error) {
+    parts := strings.SplitN(strings.TrimSpace(rate), "/", 2)
+    if len(parts) != 2 {
+        return 0, 0, fmt.Errorf("throttle: rate [%s] is invalid", rate)
+    }
+
+    max, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+    if err != nil || max <= 0 {
+        return 0, 0, fmt.Errorf("throttle: rate [%s] is invalid", rate)
+    }
+
+    unit := strings.ToLower(strings.TrimSpace(parts[1]))
+    if decay, ok := rateUnits[unit]; ok {
+        return max, decay, nil
+    }
+
+    // 复数形式，比如 minutes
+    if decay, ok := rateUnits[strings.TrimSuffix(unit, "s")]; ok && len(unit) > 2 {
+        return max, decay, nil
+    }
+
+    decay, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+    if err != nil || decay <= 0 {
+        return 0, 0, fmt.Errorf("throttle: rate [%s] is invalid", rate)
+    }
+
+    return max, decay, nil
+}
@@ -0,0 +1,154 @@
+package throttle
+
+import (
+    "fmt"
+    "math"
+    "time"
+
+    "github.com/deatil/lakego-doak/lakego/cache"
+)
+
+/**
+ * 限流结果
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Result struct {
+    // 是否允许请求
+    Allowed bool
+
+    // 时间内最多请求次数
+    Limit int
+
+    // 剩余次数
+    Remaining int
+
+    // 需要等待的时间
+    RetryAfter time.Duration
+
+    // 当前窗口重置时间
+    ResetAt time.Time
+}
+
+// 记录一次请求
+func Hit(c *cache.Cache, key string, limiter *Limiter, now time.Time) (Result, error) {
+    if limiter.Algorithm == SlidingWindow {
+        return hitSliding(c, key, limiter, now)
+    }
+
+    return hitFixed(c, key, limiter, now)
+}
+
+// 固定窗口，窗口内计数超过限制时拒绝
+func hitFixed(c *cache.Cache, key string, limiter *Limiter, now time.Time) (Result, error) {
+    window, resetAt := currentWindow(limiter.Decay, now)
+
+    windowKey := fmt.Sprintf("%s:%d", key, window)
+
+    count, err := c.Hit(windowKey, ttlSeconds(limiter.Decay))
+    if err != nil {
+        return Result{}, err
+    }
+
+    result := Result{
+        Allowed:   count <= int64(limiter.MaxAttempts),
+        Limit:     limiter.MaxAttempts,
+        Remaining: remaining(limiter.MaxAttempts, float64(count)),
+        ResetAt:   resetAt,
+    }
+
+    if !result.Allowed {
+        result.RetryAfter = resetAt.Sub(now)
+    }
+
+    return result, nil
+}
+
+// 滑动窗口，使用上个窗口的计数按剩余比例加上当前窗口的计数估算
+func hitSliding(c *cache.Cache, key string, limiter *Limiter, now time.Time) (Result, error) {
+    window, resetAt := currentWindow(limiter.Decay, now)
+
+    currentKey := fmt.Sprintf("%s:%d", key, window)
+    previousKey := fmt.Sprintf("%s:%d", key, window - 1)
+
+    // 上个窗口在当前时间点的剩余比例
+    weight := float64(resetAt.Sub(now)) / float64(limiter.Decay)
+
+    // 判断和计数一起完成，上个窗口的计数在下个窗口还会用到，保留两个窗口的时间
+    previous, current, allowed, err := c.HitSliding(
+        previousKey, currentKey,
+        weight, int64(limiter.MaxAttempts),
+        ttlSeconds(2 * limiter.Decay),
+    )
+    if err != nil {
+        return Result{}, err
+    }
+
+    result := Result{
+        Allowed: allowed,
+        Limit:   limiter.MaxAttempts,
+        ResetAt: resetAt,
+    }
+
+    if !allowed {
+        result.RetryAfter = slidingRetryAfter(previous, current, limiter, resetAt.Sub(now))
+
+        return result, nil
+    }
+
+    result.Remaining = remaining(limiter.MaxAttempts, float64(previous) * weight + float64(current))
+
+    return result, nil
+}
+
+// 滑动窗口需要等待的时间
+func slidingRetryAfter(previous int64, current int64, limiter *Limiter, left time.Duration) time.Duration {
+    max := float64(limiter.MaxAttempts)
+
+    // 当前窗口已满，等待到下个窗口中当前窗口的计数降到限制以下
+    if float64(current) + 1 > max {
+        weight := (max - 1) / float64(current)
+        return left + time.Duration((1 - weight) * float64(limiter.Decay))
+    }
+
+    if previous == 0 {
+        return left
+    }
+
+    // 等待到上个窗口的剩余计数降到可用
+    weight := (max - 1 - float64(current)) / float64(previous)
+    wait := left - time.Duration(weight * float64(limiter.Decay))
+    if wait < time.Second {
+        wait = time.Second
+    }
+
+    return wait
+}
+
+// 当前窗口序号及窗口结束时间
+func currentWindow(decay time.Duration, now time.Time) (int64, time.Time) {
+    window := now.UnixNano() / int64(decay)
+
+    return window, time.Unix(0, (window + 1) * int64(decay))
+}
+
+// 剩余次数
+func remaining(max int, used float64) int {
+    left := max - int(math.Ceil(used))
+    if left < 0 {
+        return 0
+    }
+
+    return left
+}
+
+// 缓存时间，单位为秒，最少 1 秒
+func ttlSeconds(d time.Duration) int64 {
+    seconds := int64(math.Ceil(d.Seconds()))
+    if seconds < 1 {
+        seconds = 1
+    }
+
+    return seconds
+}
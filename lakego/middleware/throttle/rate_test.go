@@ -0,0 +1,129 @@
+package throttle
+
+import (
+    "time"
+    "testing"
+    "reflect"
+
+    "github.com/deatil/lakego-doak/lakego/cache"
+    "github.com/deatil/lakego-doak/lakego/cache/driver/memory"
+    "github.com/deatil/lakego-doak/lakego/cache/interfaces"
+)
+
+func assertT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+// 只有基础方法的驱动，用于测试没有计数驱动时的处理
+type plainDriver struct {
+    interfaces.Driver
+}
+
+func Test_ParseRate(t *testing.T) {
+    assert := assertT(t)
+
+    rates := map[string]time.Duration{
+        "60/minute":  time.Minute,
+        "10/second":  time.Second,
+        "10/seconds": time.Second,
+        "1000/hour":  time.Hour,
+        "5/day":      24 * time.Hour,
+        "10/s":       time.Second,
+        "10/m":       time.Minute,
+        "10/10m":     10 * time.Minute,
+    }
+
+    for rate, expected := range rates {
+        _, decay, err := ParseRate(rate)
+        assert(err, nil, "ParseRate error " + rate)
+        assert(decay, expected, "ParseRate " + rate)
+    }
+
+    max, _, _ := ParseRate(" 60 / minute ")
+    assert(max, 60, "ParseRate max")
+
+    for _, rate := range []string{"", "60", "0/minute", "a/minute", "10/ms", "10/week", "10/-1m"} {
+        _, _, err := ParseRate(rate)
+        assert(err != nil, true, "ParseRate invalid " + rate)
+    }
+}
+
+func Test_HitFixed(t *testing.T) {
+    assert := assertT(t)
+
+    c := cache.New(memory.New())
+    limiter := &Limiter{MaxAttempts: 2, Decay: time.Minute, Algorithm: FixedWindow}
+
+    now := time.Unix(630, 0)
+
+    result, _ := Hit(c, "fixed", limiter, now)
+    assert(result.Allowed, true, "hitFixed first")
+    assert(result.Remaining, 1, "hitFixed first remaining")
+    assert(result.ResetAt, time.Unix(660, 0), "hitFixed reset at")
+
+    result, _ = Hit(c, "fixed", limiter, now)
+    assert(result.Allowed, true, "hitFixed second")
+    assert(result.Remaining, 0, "hitFixed second remaining")
+
+    result, _ = Hit(c, "fixed", limiter, now.Add(10 * time.Second))
+    assert(result.Allowed, false, "hitFixed over limit")
+    assert(result.RetryAfter, 20 * time.Second, "hitFixed retry after")
+
+    // 下个窗口重新计数
+    result, _ = Hit(c, "fixed", limiter, time.Unix(660, 0))
+    assert(result.Allowed, true, "hitFixed next window")
+    assert(result.Remaining, 1, "hitFixed next window remaining")
+}
+
+func Test_HitSliding(t *testing.T) {
+    drivers := map[string]interfaces.Driver{
+        "memory": memory.New(),
+        "plain":  plainDriver{memory.New()},
+    }
+
+    for name, driver := range drivers {
+        testHitSliding(t, name, cache.New(driver))
+    }
+}
+
+func testHitSliding(t *testing.T, name string, c *cache.Cache) {
+    assert := assertT(t)
+
+    limiter := &Limiter{MaxAttempts: 4, Decay: time.Minute, Algorithm: SlidingWindow}
+
+    now := time.Unix(600, 0)
+    for i := 0; i < 4; i++ {
+        result, err := Hit(c, "sliding", limiter, now)
+        assert(err, nil, name + " hitSliding error")
+        assert(result.Allowed, true, name + " hitSliding allowed")
+        assert(result.Remaining, 3 - i, name + " hitSliding remaining")
+    }
+
+    // 当前窗口已满，等待到下个窗口中上个窗口的计数降到可用
+    result, _ := Hit(c, "sliding", limiter, now)
+    assert(result.Allowed, false, name + " hitSliding current full")
+    assert(result.RetryAfter, 75 * time.Second, name + " hitSliding current full retry after")
+
+    // 下个窗口的一半，上个窗口计数按一半计算
+    now = time.Unix(690, 0)
+
+    result, _ = Hit(c, "sliding", limiter, now)
+    assert(result.Allowed, true, name + " hitSliding next window")
+    assert(result.Remaining, 1, name + " hitSliding next window remaining")
+
+    result, _ = Hit(c, "sliding", limiter, now)
+    assert(result.Allowed, true, name + " hitSliding next window second")
+    assert(result.Remaining, 0, name + " hitSliding next window second remaining")
+
+    result, _ = Hit(c, "sliding", limiter, now)
+    assert(result.Allowed, false, name + " hitSliding previous full")
+    assert(result.RetryAfter, 15 * time.Second, name + " hitSliding previous full retry after")
+
+    // 拒绝的请求不计数
+    result, _ = Hit(c, "sliding", limiter, now.Add(15 * time.Second))
+    assert(result.Allowed, true, name + " hitSliding after retry")
+}
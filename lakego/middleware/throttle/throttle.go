@@ -0,0 +1,220 @@
+package throttle
+
+import (
+    "fmt"
+    "sync"
+    "math"
+    "time"
+    "net/http"
+
+    "github.com/deatil/lakego-doak/lakego/cache"
+    "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/facade/config"
+    "github.com/deatil/lakego-doak/lakego/facade/logger"
+    cacheFacade "github.com/deatil/lakego-doak/lakego/facade/cache"
+)
+
+// 默认限流器名称
+const DefaultName = "default"
+
+// 中间件别名前缀
+const AliasPrefix = "throttle"
+
+// 缓存前缀
+const cachePrefix = "throttle"
+
+var (
+    mu sync.RWMutex
+
+    // 已注册限流器
+    limiters = make(map[string]*Limiter)
+
+    // 使用的缓存
+    limiterCache *cache.Cache
+)
+
+/**
+ * 请求限流，超出限制时返回 429
+ *
+ * 配置读取 server.throttle，也可以使用 throttle.For 在代码中添加
+ *
+ * throttle:
+ *   cache-store: redis
+ *   limiters:
+ *     default:
+ *       rate: 60/minute
+ *       by: ip
+ *     login:
+ *       rate: 5/minute
+ *       by: ip,route
+ *       algorithm: sliding
+ *
+ * 中间件别名为 throttle 及 throttle:<name>
+ * router.MiddlewareGroup("api", []any{"throttle:login"})
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+func Handler(name ...string) router.HandlerFunc {
+    limiterName := DefaultName
+    if len(name) > 0 && name[0] != "" {
+        limiterName = name[0]
+    }
+
+    return func(ctx *router.Context) {
+        limiter := Get(limiterName)
+        if limiter == nil {
+            logger.Default.Warnf("throttle: limiter [%s] is not defined", limiterName)
+
+            ctx.Next()
+            return
+        }
+
+        handle(ctx, limiterName, limiter)
+    }
+}
+
+// 使用限流器
+func HandlerWithLimiter(name string, limiter *Limiter) router.HandlerFunc {
+    return func(ctx *router.Context) {
+        handle(ctx, name, limiter)
+    }
+}
+
+// 添加限流器，同时注册中间件别名 throttle:<name>
+func For(name string, limiter *Limiter) {
+    mu.Lock()
+    limiters[name] = limiter
+    mu.Unlock()
+
+    registerAlias(name)
+}
+
+// 获取限流器，代码中添加的优先，没有时读取配置
+func Get(name string) *Limiter {
+    mu.RLock()
+    limiter, ok := limiters[name]
+    mu.RUnlock()
+
+    if ok {
+        return limiter
+    }
+
+    limiter, err := limiterFromConfig(name)
+    if err != nil {
+        logger.Default.Errorf("throttle: limiter [%s] config error: %s", name, err.Error())
+        return nil
+    }
+
+    if limiter == nil {
+        return nil
+    }
+
+    mu.Lock()
+    limiters[name] = limiter
+    mu.Unlock()
+
+    return limiter
+}
+
+// 设置使用的缓存
+func WithCache(c *cache.Cache) {
+    mu.Lock()
+    defer mu.Unlock()
+
+    limiterCache = c
+}
+
+// 使用的缓存，未设置时使用配置的 cache-store，没有配置时使用默认缓存
+func GetCache() *cache.Cache {
+    mu.Lock()
+    defer mu.Unlock()
+
+    if limiterCache == nil {
+        store := config.New("server").GetString("throttle.cache-store")
+        if store != "" {
+            limiterCache = cacheFacade.NewWithType(store, true)
+        } else {
+            limiterCache = cacheFacade.Default
+        }
+    }
+
+    return limiterCache
+}
+
+// 注册中间件别名，包括 throttle 及配置中的全部限流器
+func RegisterAliases() {
+    router.AliasMiddleware(AliasPrefix, Handler())
+
+    conf := config.New("server").GetStringMap("throttle.limiters")
+    for name, _ := range conf {
+        registerAlias(name)
+    }
+}
+
+// 注册单个限流器的中间件别名
+func registerAlias(name string) {
+    router.AliasMiddleware(AliasPrefix + ":" + name, Handler(name))
+}
+
+// 配置中的限流器，没有配置时返回 nil
+func limiterFromConfig(name string) (*Limiter, error) {
+    conf := config.New("server")
+
+    prefix := "throttle.limiters." + name
+    rate := conf.GetString(prefix + ".rate")
+    if rate == "" {
+        return nil, nil
+    }
+
+    limiter, err := NewLimiter(rate)
+    if err != nil {
+        return nil, err
+    }
+
+    if by := conf.GetString(prefix + ".by"); by != "" {
+        limiter.WithBy(by)
+    }
+
+    if algorithm := conf.GetString(prefix + ".algorithm"); algorithm != "" {
+        limiter.WithAlgorithm(algorithm)
+    }
+
+    return limiter, nil
+}
+
+// 限流处理，缓存出错时不限流
+func handle(ctx *router.Context, name string, limiter *Limiter) {
+    key := fmt.Sprintf("%s:%s:%s", cachePrefix, name, limiter.Key(ctx))
+
+    result, err := Hit(GetCache(), key, limiter, time.Now())
+    if err != nil {
+        logger.Default.Errorf("throttle: [%s] hit failed: %s", name, err.Error())
+
+        ctx.Next()
+        return
+    }
+
+    header := ctx.Writer.Header()
+    header.Set("X-RateLimit-Limit", fmt.Sprintf("%d", result.Limit))
+    header.Set("X-RateLimit-Remaining", fmt.Sprintf("%d", result.Remaining))
+    header.Set("X-RateLimit-Reset", fmt.Sprintf("%d", result.ResetAt.Unix()))
+
+    if result.Allowed {
+        ctx.Next()
+        return
+    }
+
+    header.Set("Retry-After", fmt.Sprintf("%d", int64(math.Ceil(result.RetryAfter.Seconds()))))
+
+    if limiter.Response != nil {
+        limiter.Response(ctx, result)
+        ctx.Abort()
+        return
+    }
+
+    ctx.AbortWithStatusJSON(http.StatusTooManyRequests, router.H{
+        "code":    http.StatusTooManyRequests,
+        "message": "Too Many Requests",
+    })
+}
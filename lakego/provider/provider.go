@@ -6,6 +6,7 @@ import (
     "github.com/deatil/lakego-filesystem/filesystem"
 
     "github.com/deatil/lakego-doak/lakego/router"
+    "github.com/deatil/lakego-doak/lakego/events"
     "github.com/deatil/lakego-doak/lakego/publish"
     "github.com/deatil/lakego-doak/lakego/command"
     "github.com/deatil/lakego-doak/lakego/facade"
@@ -168,6 +169,17 @@ func (this *ServiceProvider) AddViewFunc(name string, fn any) {
     view_func.AddFunc(name, fn)
 }
 
+// 监听事件，在 Register 中注册
+// this.AddListener(UserLoggedIn{}, func(ctx context.Context, event any) error { return nil })
+func (this *ServiceProvider) AddListener(event any, listener events.Listener, opts ...events.Option) *events.Subscription {
+    return events.Default().Listen(event, listener, opts...)
+}
+
+// 通配监听事件
+func (this *ServiceProvider) AddWildcardListener(pattern string, listener events.Listener, opts ...events.Option) *events.Subscription {
+    return events.Default().ListenWildcard(pattern, listener, opts...)
+}
+
 // 推送
 func (this *ServiceProvider) Publishes(obj any, paths map[string]string, group string) {
     publish.Instance().Publish(obj, paths, group)
@@ -0,0 +1,198 @@
+package database
+
+import (
+    "time"
+    "context"
+    "encoding/json"
+
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+
+    "github.com/deatil/lakego-doak/lakego/queue/interfaces"
+)
+
+const (
+    // 默认表名
+    DefaultTable = "jobs"
+
+    // 默认保留时间
+    DefaultRetryAfter = 90 * time.Second
+)
+
+/**
+ * 任务表
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Job struct {
+    ID          string `gorm:"column:id;size:36;primaryKey;not null;"`
+    Queue       string `gorm:"column:queue;size:100;not null;index:idx_queue_available,priority:1;"`
+    Job         string `gorm:"column:job;size:255;not null;"`
+    Payload     string `gorm:"column:payload;type:longtext;not null;"`
+    Attempts    int    `gorm:"column:attempts;not null;default:0;"`
+    ReservedAt  int64  `gorm:"column:reserved_at;not null;default:0;"`
+    AvailableAt int64  `gorm:"column:available_at;not null;index:idx_queue_available,priority:2;"`
+    CreatedAt   int64  `gorm:"column:created_at;not null;"`
+}
+
+/**
+ * 数据库驱动
+ *
+ * 已保留的任务超过保留时间未完成时可以被重新取出
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Database struct {
+    // 数据库
+    db *gorm.DB
+
+    // 表名
+    table string
+
+    // 保留时间，需要大于任务的超时时间
+    retryAfter time.Duration
+}
+
+// 构造函数，表不存在时自动创建
+func New(db *gorm.DB, table string, retryAfter time.Duration) *Database {
+    if table == "" {
+        table = DefaultTable
+    }
+
+    if retryAfter <= 0 {
+        retryAfter = DefaultRetryAfter
+    }
+
+    if !db.Migrator().HasTable(table) {
+        db.Table(table).AutoMigrate(&Job{})
+    }
+
+    return &Database{
+        db:         db,
+        table:      table,
+        retryAfter: retryAfter,
+    }
+}
+
+// 保留时间
+func (this *Database) RetryAfter() time.Duration {
+    return this.retryAfter
+}
+
+// 推送任务
+func (this *Database) Push(ctx context.Context, msg *interfaces.Message) error {
+    data, err := json.Marshal(msg)
+    if err != nil {
+        return err
+    }
+
+    return this.query(ctx).Create(&Job{
+        ID:          msg.ID,
+        Queue:       msg.Queue,
+        Job:         msg.Job,
+        Payload:     string(data),
+        Attempts:    msg.Attempts,
+        AvailableAt: msg.AvailableAt.Unix(),
+        CreatedAt:   msg.CreatedAt.Unix(),
+    }).Error
+}
+
+// 取出任务
+func (this *Database) Pop(ctx context.Context, queue string) (*interfaces.Message, error) {
+    var msg *interfaces.Message
+
+    err := this.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+        now := time.Now()
+
+        var job Job
+        err := tx.Table(this.table).
+            Clauses(clause.Locking{Strength: "UPDATE"}).
+            Where("queue = ?", queue).
+            Where(
+                this.db.Where("reserved_at = ? AND available_at <= ?", 0, now.Unix()).
+                    Or("reserved_at > ? AND reserved_at <= ?", 0, now.Add(-this.retryAfter).Unix()),
+            ).
+            Order("available_at asc").
+            Limit(1).
+            Find(&job).
+            Error
+        if err != nil || job.ID == "" {
+            return err
+        }
+
+        job.Attempts++
+
+        err = tx.Table(this.table).
+            Where("id = ?", job.ID).
+            Updates(map[string]any{
+                "attempts":    job.Attempts,
+                "reserved_at": now.Unix(),
+            }).
+            Error
+        if err != nil {
+            return err
+        }
+
+        msg = &interfaces.Message{}
+        if err := json.Unmarshal([]byte(job.Payload), msg); err != nil {
+            return err
+        }
+
+        msg.Attempts = job.Attempts
+
+        return nil
+    })
+
+    if err != nil {
+        return nil, err
+    }
+
+    return msg, nil
+}
+
+// 删除任务
+func (this *Database) Delete(ctx context.Context, msg *interfaces.Message) error {
+    return this.query(ctx).
+        Where("id = ?", msg.ID).
+        Delete(&Job{}).
+        Error
+}
+
+// 放回队列
+func (this *Database) Release(ctx context.Context, msg *interfaces.Message, delay time.Duration) error {
+    return this.query(ctx).
+        Where("id = ?", msg.ID).
+        Updates(map[string]any{
+            "attempts":     msg.Attempts,
+            "reserved_at":  0,
+            "available_at": time.Now().Add(delay).Unix(),
+        }).
+        Error
+}
+
+// 队列任务数量，包括延迟及已保留的任务
+func (this *Database) Size(ctx context.Context, queue string) (int64, error) {
+    var count int64
+
+    err := this.query(ctx).
+        Where("queue = ?", queue).
+        Count(&count).
+        Error
+
+    return count, err
+}
+
+// 清空队列
+func (this *Database) Clear(ctx context.Context, queue string) error {
+    return this.query(ctx).
+        Where("queue = ?", queue).
+        Delete(&Job{}).
+        Error
+}
+
+// 查询
+func (this *Database) query(ctx context.Context) *gorm.DB {
+    return this.db.WithContext(ctx).Table(this.table)
+}
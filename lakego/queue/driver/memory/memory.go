@@ -0,0 +1,137 @@
+package memory
+
+import (
+    "sync"
+    "time"
+    "context"
+
+    "github.com/deatil/lakego-doak/lakego/queue/interfaces"
+)
+
+/**
+ * 内存驱动，只在当前进程内有效
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Memory struct {
+    // 锁
+    mu sync.Mutex
+
+    // 等待中的任务
+    queues map[string][]*interfaces.Message
+
+    // 已保留的任务
+    reserved map[string]*interfaces.Message
+}
+
+// 构造函数
+func New() *Memory {
+    return &Memory{
+        queues:   make(map[string][]*interfaces.Message),
+        reserved: make(map[string]*interfaces.Message),
+    }
+}
+
+// 推送任务
+func (this *Memory) Push(ctx context.Context, msg *interfaces.Message) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.queues[msg.Queue] = append(this.queues[msg.Queue], msg.Clone())
+
+    return nil
+}
+
+// 取出可执行时间最早的任务
+func (this *Memory) Pop(ctx context.Context, queue string) (*interfaces.Message, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    now := time.Now()
+
+    list := this.queues[queue]
+
+    index := -1
+    for i, msg := range list {
+        if msg.AvailableAt.After(now) {
+            continue
+        }
+
+        if index < 0 || msg.AvailableAt.Before(list[index].AvailableAt) {
+            index = i
+        }
+    }
+
+    if index < 0 {
+        return nil, nil
+    }
+
+    msg := list[index]
+
+    newList := make([]*interfaces.Message, 0, len(list) - 1)
+    newList = append(newList, list[:index]...)
+    newList = append(newList, list[index+1:]...)
+    this.queues[queue] = newList
+
+    msg.Attempts++
+    this.reserved[msg.ID] = msg
+
+    return msg.Clone(), nil
+}
+
+// 删除任务
+func (this *Memory) Delete(ctx context.Context, msg *interfaces.Message) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    delete(this.reserved, msg.ID)
+
+    return nil
+}
+
+// 放回队列
+func (this *Memory) Release(ctx context.Context, msg *interfaces.Message, delay time.Duration) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    delete(this.reserved, msg.ID)
+
+    newMsg := msg.Clone()
+    newMsg.AvailableAt = time.Now().Add(delay)
+
+    this.queues[msg.Queue] = append(this.queues[msg.Queue], newMsg)
+
+    return nil
+}
+
+// 队列任务数量，包括延迟及已保留的任务
+func (this *Memory) Size(ctx context.Context, queue string) (int64, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    size := int64(len(this.queues[queue]))
+    for _, msg := range this.reserved {
+        if msg.Queue == queue {
+            size++
+        }
+    }
+
+    return size, nil
+}
+
+// 清空队列
+func (this *Memory) Clear(ctx context.Context, queue string) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    delete(this.queues, queue)
+
+    for id, msg := range this.reserved {
+        if msg.Queue == queue {
+            delete(this.reserved, id)
+        }
+    }
+
+    return nil
+}
@@ -0,0 +1,226 @@
+package redis
+
+import (
+    "time"
+    "context"
+    "encoding/json"
+
+    "github.com/go-redis/redis/v8"
+
+    "github.com/deatil/lakego-doak/lakego/queue/interfaces"
+)
+
+// 默认保留时间
+const DefaultRetryAfter = 90 * time.Second
+
+// 取出任务
+// 先将到期的延迟任务及保留超时的任务移回队列，再取出一个任务并保留
+// 保留的任务已增加执行次数，超时重新放回队列时次数不会丢失
+var popScript = redis.NewScript(`
+local due = redis.call('zrangebyscore', KEYS[2], '-inf', ARGV[1])
+for i, job in ipairs(due) do
+    redis.call('rpush', KEYS[1], job)
+end
+if #due > 0 then
+    redis.call('zremrangebyscore', KEYS[2], '-inf', ARGV[1])
+end
+
+local expired = redis.call('zrangebyscore', KEYS[3], '-inf', ARGV[1])
+for i, id in ipairs(expired) do
+    local job = redis.call('hget', KEYS[4], id)
+    if job then
+        redis.call('rpush', KEYS[1], job)
+    end
+    redis.call('hdel', KEYS[4], id)
+end
+if #expired > 0 then
+    redis.call('zremrangebyscore', KEYS[3], '-inf', ARGV[1])
+end
+
+local job = redis.call('lpop', KEYS[1])
+if job then
+    -- 增加执行次数，最后一个 attempts 为任务本身的字段
+    local prefix, attempts, rest = string.match(job, '^(.*"attempts":)(%d+)(.*)$')
+    if prefix then
+        job = prefix .. (tonumber(attempts) + 1) .. rest
+    end
+
+    local id = cjson.decode(job)['id']
+    redis.call('zadd', KEYS[3], ARGV[2], id)
+    redis.call('hset', KEYS[4], id, job)
+end
+
+return job
+`)
+
+/**
+ * redis 驱动
+ *
+ * 队列使用 list 保存，延迟任务使用 zset 保存，
+ * 已保留的任务超过保留时间未完成时会重新放回队列
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Redis struct {
+    // 客户端
+    client *redis.Client
+
+    // 前缀
+    prefix string
+
+    // 保留时间，需要大于任务的超时时间
+    retryAfter time.Duration
+}
+
+// 构造函数
+func New(client *redis.Client, prefix string, retryAfter time.Duration) *Redis {
+    if retryAfter <= 0 {
+        retryAfter = DefaultRetryAfter
+    }
+
+    return &Redis{
+        client:     client,
+        prefix:     prefix,
+        retryAfter: retryAfter,
+    }
+}
+
+// 保留时间
+func (this *Redis) RetryAfter() time.Duration {
+    return this.retryAfter
+}
+
+// 推送任务
+func (this *Redis) Push(ctx context.Context, msg *interfaces.Message) error {
+    data, err := json.Marshal(msg)
+    if err != nil {
+        return err
+    }
+
+    return this.push(ctx, this.client, msg, data)
+}
+
+// 取出任务
+func (this *Redis) Pop(ctx context.Context, queue string) (*interfaces.Message, error) {
+    now := time.Now()
+
+    keys := []string{
+        this.key(queue),
+        this.delayedKey(queue),
+        this.reservedKey(queue),
+        this.reservedDataKey(queue),
+    }
+
+    res, err := popScript.Run(ctx, this.client, keys,
+        now.Unix(),
+        now.Add(this.retryAfter).Unix(),
+    ).Result()
+    if err == redis.Nil {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, err
+    }
+
+    data, ok := res.(string)
+    if !ok {
+        return nil, nil
+    }
+
+    msg := &interfaces.Message{}
+    if err := json.Unmarshal([]byte(data), msg); err != nil {
+        return nil, err
+    }
+
+    return msg, nil
+}
+
+// 删除任务
+func (this *Redis) Delete(ctx context.Context, msg *interfaces.Message) error {
+    _, err := this.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+        pipe.ZRem(ctx, this.reservedKey(msg.Queue), msg.ID)
+        pipe.HDel(ctx, this.reservedDataKey(msg.Queue), msg.ID)
+
+        return nil
+    })
+
+    return err
+}
+
+// 放回队列
+func (this *Redis) Release(ctx context.Context, msg *interfaces.Message, delay time.Duration) error {
+    newMsg := msg.Clone()
+    newMsg.AvailableAt = time.Now().Add(delay)
+
+    data, err := json.Marshal(newMsg)
+    if err != nil {
+        return err
+    }
+
+    _, err = this.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+        pipe.ZRem(ctx, this.reservedKey(msg.Queue), msg.ID)
+        pipe.HDel(ctx, this.reservedDataKey(msg.Queue), msg.ID)
+
+        return this.push(ctx, pipe, newMsg, data)
+    })
+
+    return err
+}
+
+// 队列任务数量，包括延迟及已保留的任务
+func (this *Redis) Size(ctx context.Context, queue string) (int64, error) {
+    pipe := this.client.Pipeline()
+
+    size := pipe.LLen(ctx, this.key(queue))
+    delayed := pipe.ZCard(ctx, this.delayedKey(queue))
+    reserved := pipe.ZCard(ctx, this.reservedKey(queue))
+
+    if _, err := pipe.Exec(ctx); err != nil {
+        return 0, err
+    }
+
+    return size.Val() + delayed.Val() + reserved.Val(), nil
+}
+
+// 清空队列
+func (this *Redis) Clear(ctx context.Context, queue string) error {
+    return this.client.Del(ctx,
+        this.key(queue),
+        this.delayedKey(queue),
+        this.reservedKey(queue),
+        this.reservedDataKey(queue),
+    ).Err()
+}
+
+// 推送，未到可执行时间的任务放入延迟列表
+func (this *Redis) push(ctx context.Context, cmd redis.Cmdable, msg *interfaces.Message, data []byte) error {
+    if msg.AvailableAt.After(time.Now()) {
+        return cmd.ZAdd(ctx, this.delayedKey(msg.Queue), &redis.Z{
+            Score:  float64(msg.AvailableAt.Unix()),
+            Member: string(data),
+        }).Err()
+    }
+
+    return cmd.RPush(ctx, this.key(msg.Queue), string(data)).Err()
+}
+
+// 队列
+func (this *Redis) key(queue string) string {
+    return this.prefix + "queues:" + queue
+}
+
+// 延迟任务
+func (this *Redis) delayedKey(queue string) string {
+    return this.key(queue) + ":delayed"
+}
+
+// 已保留的任务 ID
+func (this *Redis) reservedKey(queue string) string {
+    return this.key(queue) + ":reserved"
+}
+
+// 已保留的任务数据
+func (this *Redis) reservedDataKey(queue string) string {
+    return this.key(queue) + ":reserved:data"
+}
@@ -0,0 +1,60 @@
+package sync
+
+import (
+    "time"
+    "errors"
+    "context"
+
+    "github.com/deatil/lakego-doak/lakego/queue/interfaces"
+)
+
+// 同步驱动不保存任务
+var ErrSyncPush = errors.New("queue: sync driver runs jobs on dispatch")
+
+/**
+ * 同步驱动，推送时直接在当前协程执行任务
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Sync struct {}
+
+// 构造函数
+func New() *Sync {
+    return &Sync{}
+}
+
+// 同步驱动
+func (this *Sync) IsSync() bool {
+    return true
+}
+
+// 推送任务
+func (this *Sync) Push(ctx context.Context, msg *interfaces.Message) error {
+    return ErrSyncPush
+}
+
+// 取出任务
+func (this *Sync) Pop(ctx context.Context, queue string) (*interfaces.Message, error) {
+    return nil, nil
+}
+
+// 删除任务
+func (this *Sync) Delete(ctx context.Context, msg *interfaces.Message) error {
+    return nil
+}
+
+// 放回队列
+func (this *Sync) Release(ctx context.Context, msg *interfaces.Message, delay time.Duration) error {
+    return nil
+}
+
+// 队列任务数量
+func (this *Sync) Size(ctx context.Context, queue string) (int64, error) {
+    return 0, nil
+}
+
+// 清空队列
+func (this *Sync) Clear(ctx context.Context, queue string) error {
+    return nil
+}
@@ -0,0 +1,97 @@
+package queue
+
+import (
+    "fmt"
+    "time"
+    "errors"
+    "context"
+    "runtime/debug"
+
+    "github.com/deatil/lakego-doak/lakego/facade/logger"
+)
+
+// 执行超时
+var ErrTimeout = errors.New("queue: job timed out")
+
+// 超时时间不小于驱动的保留时间
+var ErrTimeoutTooLong = errors.New("queue: timeout must be less than retry-after")
+
+/**
+ * 任务 panic
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type PanicError struct {
+    // panic 的值
+    Value any
+
+    // 调用栈
+    Stack []byte
+}
+
+// 错误信息
+func (this *PanicError) Error() string {
+    return fmt.Sprintf("queue: job panic: %v", this.Value)
+}
+
+// 错误的调用栈，panic 时为 panic 的调用栈
+func ErrorStack(err error) string {
+    var perr *PanicError
+    if errors.As(err, &perr) {
+        return string(perr.Stack)
+    }
+
+    return fmt.Sprintf("%+v", err)
+}
+
+// 失败处理，任务执行次数用完或者无法还原时调用
+type FailedHandler func(ctx context.Context, msg *Message, err error)
+
+// 默认使用日志记录
+func DefaultFailedHandler(ctx context.Context, msg *Message, err error) {
+    fields := map[string]any{
+        "id":       msg.ID,
+        "queue":    msg.Queue,
+        "job":      msg.Job,
+        "attempts": msg.Attempts,
+        "payload":  string(msg.Payload),
+    }
+
+    var perr *PanicError
+    if errors.As(err, &perr) {
+        fields["stack"] = string(perr.Stack)
+    }
+
+    logger.Default.
+        WithFields(fields).
+        WithError(err).
+        Error("queue: job failed")
+}
+
+// 执行任务，捕获 panic 及超时
+// 超时只会取消 ctx，任务需要处理 ctx.Done()，这里会等待任务返回，
+// 避免任务放回队列后和仍在运行的任务同时执行
+func runJob(ctx context.Context, job Job, timeout time.Duration) (err error) {
+    if timeout > 0 {
+        var cancel context.CancelFunc
+        ctx, cancel = context.WithTimeout(ctx, timeout)
+        defer cancel()
+    }
+
+    defer func() {
+        if r := recover(); r != nil {
+            err = &PanicError{
+                Value: r,
+                Stack: debug.Stack(),
+            }
+        }
+    }()
+
+    err = job.Handle(ctx)
+    if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+        return ErrTimeout
+    }
+
+    return err
+}
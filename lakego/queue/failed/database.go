@@ -0,0 +1,147 @@
+package failed
+
+import (
+    "time"
+    "context"
+
+    "gorm.io/gorm"
+
+    "github.com/deatil/lakego-doak/lakego/queue/interfaces"
+)
+
+// 默认表名
+const DefaultTable = "failed_jobs"
+
+/**
+ * 失败任务表
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type FailedJob struct {
+    ID         uint64 `gorm:"column:id;primaryKey;autoIncrement;"`
+    UUID       string `gorm:"column:uuid;size:36;not null;uniqueIndex;"`
+    Connection string `gorm:"column:connection;size:100;not null;"`
+    Queue      string `gorm:"column:queue;size:100;not null;"`
+    Job        string `gorm:"column:job;size:255;not null;"`
+    Payload    string `gorm:"column:payload;type:longtext;not null;"`
+    Exception  string `gorm:"column:exception;type:longtext;not null;"`
+    Stack      string `gorm:"column:stack;type:longtext;"`
+    FailedAt   int64  `gorm:"column:failed_at;not null;index;"`
+}
+
+/**
+ * 数据库存储
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Database struct {
+    // 数据库
+    db *gorm.DB
+
+    // 表名
+    table string
+}
+
+// 构造函数，表不存在时自动创建
+func NewDatabase(db *gorm.DB, table string) *Database {
+    if table == "" {
+        table = DefaultTable
+    }
+
+    if !db.Migrator().HasTable(table) {
+        db.Table(table).AutoMigrate(&FailedJob{})
+    }
+
+    return &Database{
+        db:    db,
+        table: table,
+    }
+}
+
+// 保存
+func (this *Database) Save(ctx context.Context, job *interfaces.FailedJob) error {
+    return this.query(ctx).Create(&FailedJob{
+        UUID:       job.ID,
+        Connection: job.Connection,
+        Queue:      job.Queue,
+        Job:        job.Job,
+        Payload:    job.Payload,
+        Exception:  job.Exception,
+        Stack:      job.Stack,
+        FailedAt:   job.FailedAt.Unix(),
+    }).Error
+}
+
+// 全部失败的任务
+func (this *Database) All(ctx context.Context) ([]*interfaces.FailedJob, error) {
+    var list []FailedJob
+
+    err := this.query(ctx).
+        Order("id desc").
+        Find(&list).
+        Error
+    if err != nil {
+        return nil, err
+    }
+
+    jobs := make([]*interfaces.FailedJob, 0, len(list))
+    for _, job := range list {
+        jobs = append(jobs, this.format(job))
+    }
+
+    return jobs, nil
+}
+
+// 查找
+func (this *Database) Find(ctx context.Context, id string) (*interfaces.FailedJob, error) {
+    var job FailedJob
+
+    err := this.query(ctx).
+        Where("uuid = ?", id).
+        Limit(1).
+        Find(&job).
+        Error
+    if err != nil || job.UUID == "" {
+        return nil, err
+    }
+
+    return this.format(job), nil
+}
+
+// 删除
+func (this *Database) Forget(ctx context.Context, id string) (bool, error) {
+    res := this.query(ctx).
+        Where("uuid = ?", id).
+        Delete(&FailedJob{})
+
+    return res.RowsAffected > 0, res.Error
+}
+
+// 清空
+func (this *Database) Flush(ctx context.Context) error {
+    return this.query(ctx).
+        Where("1 = 1").
+        Delete(&FailedJob{}).
+        Error
+}
+
+// 转换
+func (this *Database) format(job FailedJob) *interfaces.FailedJob {
+    return &interfaces.FailedJob{
+        ID:         job.UUID,
+        Connection: job.Connection,
+        Queue:      job.Queue,
+        Job:        job.Job,
+        Payload:    job.Payload,
+        Exception:  job.Exception,
+        Stack:      job.Stack,
+        FailedAt:   time.Unix(job.FailedAt, 0),
+    }
+}
+
+// 查询
+func (this *Database) query(ctx context.Context) *gorm.DB {
+    return this.db.WithContext(ctx).Table(this.table)
+}
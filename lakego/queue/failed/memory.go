@@ -0,0 +1,92 @@
+package failed
+
+import (
+    "sync"
+    "context"
+
+    "github.com/deatil/lakego-doak/lakego/queue/interfaces"
+)
+
+/**
+ * 内存存储，只在当前进程内有效
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Memory struct {
+    // 锁
+    mu sync.RWMutex
+
+    // 失败的任务
+    jobs []*interfaces.FailedJob
+}
+
+// 构造函数
+func NewMemory() *Memory {
+    return &Memory{}
+}
+
+// 保存
+func (this *Memory) Save(ctx context.Context, job *interfaces.FailedJob) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    newJob := *job
+    this.jobs = append(this.jobs, &newJob)
+
+    return nil
+}
+
+// 全部失败的任务
+func (this *Memory) All(ctx context.Context) ([]*interfaces.FailedJob, error) {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    jobs := make([]*interfaces.FailedJob, 0, len(this.jobs))
+    for i := len(this.jobs) - 1; i >= 0; i-- {
+        job := *this.jobs[i]
+        jobs = append(jobs, &job)
+    }
+
+    return jobs, nil
+}
+
+// 查找
+func (this *Memory) Find(ctx context.Context, id string) (*interfaces.FailedJob, error) {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    for _, job := range this.jobs {
+        if job.ID == id {
+            newJob := *job
+            return &newJob, nil
+        }
+    }
+
+    return nil, nil
+}
+
+// 删除
+func (this *Memory) Forget(ctx context.Context, id string) (bool, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    for i, job := range this.jobs {
+        if job.ID == id {
+            this.jobs = append(this.jobs[:i:i], this.jobs[i+1:]...)
+            return true, nil
+        }
+    }
+
+    return false, nil
+}
+
+// 清空
+func (this *Memory) Flush(ctx context.Context) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.jobs = nil
+
+    return nil
+}
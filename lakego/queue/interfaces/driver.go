@@ -0,0 +1,42 @@
+package interfaces
+
+import (
+    "time"
+    "context"
+)
+
+/**
+ * 队列驱动接口
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Driver interface {
+    // 推送任务，AvailableAt 之后才能取出
+    Push(ctx context.Context, msg *Message) error
+
+    // 取出一个可执行的任务并保留，取出时 Attempts 加 1，没有任务时返回 nil
+    Pop(ctx context.Context, queue string) (*Message, error)
+
+    // 删除已保留的任务
+    Delete(ctx context.Context, msg *Message) error
+
+    // 将已保留的任务延迟放回队列
+    Release(ctx context.Context, msg *Message, delay time.Duration) error
+
+    // 队列任务数量
+    Size(ctx context.Context, queue string) (int64, error)
+
+    // 清空队列
+    Clear(ctx context.Context, queue string) error
+}
+
+// 同步驱动，推送时直接执行任务
+type SyncDriver interface {
+    IsSync() bool
+}
+
+// 保留超时后重新放回队列的驱动，任务的超时时间需要小于保留时间
+type RetryAfterDriver interface {
+    RetryAfter() time.Duration
+}
@@ -0,0 +1,61 @@
+package interfaces
+
+import (
+    "time"
+    "context"
+)
+
+/**
+ * 失败的任务
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type FailedJob struct {
+    // 消息 ID
+    ID string
+
+    // 连接名称
+    Connection string
+
+    // 队列名称
+    Queue string
+
+    // 任务名称
+    Job string
+
+    // 消息数据
+    Payload string
+
+    // 错误信息
+    Exception string
+
+    // 调用栈
+    Stack string
+
+    // 失败时间
+    FailedAt time.Time
+}
+
+/**
+ * 失败任务存储接口
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type FailedStore interface {
+    // 保存
+    Save(ctx context.Context, job *FailedJob) error
+
+    // 全部失败的任务，按失败时间倒序
+    All(ctx context.Context) ([]*FailedJob, error)
+
+    // 查找，不存在时返回 nil
+    Find(ctx context.Context, id string) (*FailedJob, error)
+
+    // 删除
+    Forget(ctx context.Context, id string) (bool, error)
+
+    // 清空
+    Flush(ctx context.Context) error
+}
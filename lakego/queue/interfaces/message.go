@@ -0,0 +1,72 @@
+package interfaces
+
+import (
+    "time"
+    "encoding/json"
+)
+
+/**
+ * 队列消息
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Message struct {
+    // 消息 ID
+    ID string `json:"id"`
+
+    // 队列名称
+    Queue string `json:"queue"`
+
+    // 任务名称
+    Job string `json:"job"`
+
+    // 任务数据
+    Payload json.RawMessage `json:"payload"`
+
+    // 已执行次数
+    Attempts int `json:"attempts"`
+
+    // 最大执行次数
+    MaxAttempts int `json:"max_attempts"`
+
+    // 重试间隔，次数超过列表时使用最后一个
+    Backoff []time.Duration `json:"backoff"`
+
+    // 执行超时时间
+    Timeout time.Duration `json:"timeout"`
+
+    // 可执行时间
+    AvailableAt time.Time `json:"available_at"`
+
+    // 创建时间
+    CreatedAt time.Time `json:"created_at"`
+}
+
+// 复制
+func (this *Message) Clone() *Message {
+    msg := *this
+
+    msg.Payload = append(json.RawMessage(nil), this.Payload...)
+    msg.Backoff = append([]time.Duration(nil), this.Backoff...)
+
+    return &msg
+}
+
+// 第 attempt 次失败后的重试间隔
+func (this *Message) BackoffFor(attempt int) time.Duration {
+    if len(this.Backoff) == 0 || attempt <= 0 {
+        return 0
+    }
+
+    if attempt > len(this.Backoff) {
+        return this.Backoff[len(this.Backoff) - 1]
+    }
+
+    return this.Backoff[attempt - 1]
+}
+
+// 是否还可以重试
+func (this *Message) CanRetry() bool {
+    return this.MaxAttempts <= 0 || this.Attempts < this.MaxAttempts
+}
@@ -0,0 +1,94 @@
+package queue
+
+import (
+    "sync"
+    "errors"
+    "reflect"
+    "context"
+    "encoding/json"
+)
+
+// 任务未注册
+var ErrJobNotRegistered = errors.New("queue: job is not registered")
+
+// 任务
+type Job interface {
+    // 执行任务，超时或者关闭时 ctx 会被取消
+    Handle(ctx context.Context) error
+}
+
+// 自定义任务名称，未实现时使用类型名称
+type NamedJob interface {
+    JobName() string
+}
+
+var (
+    // 任务类型锁
+    jobsMu sync.RWMutex
+
+    // 已注册的任务类型
+    jobs = make(map[string]reflect.Type)
+)
+
+// 注册任务，执行队列的进程需要先注册任务才能还原
+// queue.RegisterJob(&SendMail{}, &ResizeImage{})
+func RegisterJob(list ...Job) {
+    jobsMu.Lock()
+    defer jobsMu.Unlock()
+
+    for _, job := range list {
+        jobs[JobName(job)] = reflect.TypeOf(job)
+    }
+}
+
+// 任务名称
+func JobName(job Job) string {
+    if named, ok := job.(NamedJob); ok {
+        return named.JobName()
+    }
+
+    typ := reflect.TypeOf(job)
+    if typ.Kind() == reflect.Ptr {
+        typ = typ.Elem()
+    }
+
+    return typ.PkgPath() + "." + typ.Name()
+}
+
+// 任务是否已注册
+func HasJob(name string) bool {
+    jobsMu.RLock()
+    defer jobsMu.RUnlock()
+
+    _, ok := jobs[name]
+    return ok
+}
+
+// 根据名称及数据还原任务
+func NewJob(name string, payload []byte) (Job, error) {
+    jobsMu.RLock()
+    typ, ok := jobs[name]
+    jobsMu.RUnlock()
+
+    if !ok {
+        return nil, ErrJobNotRegistered
+    }
+
+    isPtr := typ.Kind() == reflect.Ptr
+    if isPtr {
+        typ = typ.Elem()
+    }
+
+    value := reflect.New(typ)
+    if len(payload) > 0 {
+        if err := json.Unmarshal(payload, value.Interface()); err != nil {
+            return nil, err
+        }
+    }
+
+    if !isPtr {
+        value = value.Elem()
+    }
+
+    return value.Interface().(Job), nil
+}
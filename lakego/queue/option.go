@@ -0,0 +1,53 @@
+package queue
+
+import (
+    "time"
+
+    "github.com/deatil/lakego-doak/lakego/queue/interfaces"
+)
+
+// 推送设置
+type Option func(*interfaces.Message)
+
+// 队列名称
+func OnQueue(name string) Option {
+    return func(msg *interfaces.Message) {
+        msg.Queue = name
+    }
+}
+
+// 延迟执行
+func Delay(delay time.Duration) Option {
+    return func(msg *interfaces.Message) {
+        msg.AvailableAt = time.Now().Add(delay)
+    }
+}
+
+// 指定时间执行
+func At(t time.Time) Option {
+    return func(msg *interfaces.Message) {
+        msg.AvailableAt = t
+    }
+}
+
+// 最大执行次数，0 为不限制
+func Tries(tries int) Option {
+    return func(msg *interfaces.Message) {
+        msg.MaxAttempts = tries
+    }
+}
+
+// 重试间隔，次数超过列表时使用最后一个
+// queue.Backoff(time.Second, 10 * time.Second, time.Minute)
+func Backoff(backoff ...time.Duration) Option {
+    return func(msg *interfaces.Message) {
+        msg.Backoff = backoff
+    }
+}
+
+// 执行超时时间，0 为不限制
+func Timeout(timeout time.Duration) Option {
+    return func(msg *interfaces.Message) {
+        msg.Timeout = timeout
+    }
+}
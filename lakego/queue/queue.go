@@ -0,0 +1,415 @@
+package queue
+
+import (
+    "fmt"
+    "time"
+    "errors"
+    "context"
+    "encoding/json"
+
+    "github.com/deatil/lakego-doak/lakego/uuid"
+    "github.com/deatil/lakego-doak/lakego/facade/logger"
+    "github.com/deatil/lakego-doak/lakego/queue/interfaces"
+)
+
+type (
+    // 配置
+    Config = map[string]any
+
+    // 消息
+    Message = interfaces.Message
+
+    // 失败的任务
+    FailedJob = interfaces.FailedJob
+)
+
+// 默认队列名称
+const DefaultQueue = "default"
+
+/**
+ * 队列
+ *
+ * q := queue.New(memory.New())
+ * q.Dispatch(&SendMail{To: "a@example.com"}, queue.OnQueue("mail"), queue.Delay(time.Minute))
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Queue struct {
+    // 配置
+    config Config
+
+    // 驱动
+    driver interfaces.Driver
+
+    // 连接名称
+    connection string
+
+    // 默认队列名称
+    queue string
+
+    // 默认最大执行次数
+    tries int
+
+    // 默认重试间隔
+    backoff []time.Duration
+
+    // 默认超时时间
+    timeout time.Duration
+
+    // 失败处理
+    failed FailedHandler
+
+    // 失败任务存储
+    failedStore interfaces.FailedStore
+}
+
+// 创建
+func New(driver interfaces.Driver, conf ...Config) *Queue {
+    q := &Queue{
+        driver: driver,
+        queue:  DefaultQueue,
+        tries:  1,
+        failed: DefaultFailedHandler,
+    }
+
+    if len(conf) > 0 {
+        q.config = conf[0]
+    }
+
+    return q
+}
+
+// 设置驱动
+func (this *Queue) WithDriver(driver interfaces.Driver) *Queue {
+    this.driver = driver
+
+    return this
+}
+
+// 获取驱动
+func (this *Queue) GetDriver() interfaces.Driver {
+    return this.driver
+}
+
+// 设置配置
+func (this *Queue) WithConfig(config Config) *Queue {
+    this.config = config
+
+    return this
+}
+
+// 获取配置
+func (this *Queue) GetConfig() Config {
+    return this.config
+}
+
+// 设置连接名称
+func (this *Queue) WithConnection(name string) *Queue {
+    this.connection = name
+
+    return this
+}
+
+// 获取连接名称
+func (this *Queue) GetConnection() string {
+    return this.connection
+}
+
+// 设置默认队列名称
+func (this *Queue) WithQueue(name string) *Queue {
+    if name != "" {
+        this.queue = name
+    }
+
+    return this
+}
+
+// 获取默认队列名称
+func (this *Queue) GetQueue() string {
+    return this.queue
+}
+
+// 设置默认最大执行次数
+func (this *Queue) WithTries(tries int) *Queue {
+    this.tries = tries
+
+    return this
+}
+
+// 设置默认重试间隔
+func (this *Queue) WithBackoff(backoff ...time.Duration) *Queue {
+    this.backoff = backoff
+
+    return this
+}
+
+// 设置默认超时时间
+func (this *Queue) WithTimeout(timeout time.Duration) *Queue {
+    this.timeout = timeout
+
+    return this
+}
+
+// 设置失败处理
+func (this *Queue) WithFailedHandler(handler FailedHandler) *Queue {
+    this.failed = handler
+
+    return this
+}
+
+// 设置失败任务存储
+func (this *Queue) WithFailedStore(store interfaces.FailedStore) *Queue {
+    this.failedStore = store
+
+    return this
+}
+
+// 获取失败任务存储
+func (this *Queue) GetFailedStore() interfaces.FailedStore {
+    return this.failedStore
+}
+
+// 推送任务
+func (this *Queue) Dispatch(job Job, opts ...Option) error {
+    return this.DispatchContext(context.Background(), job, opts...)
+}
+
+// 延迟推送任务
+func (this *Queue) Later(delay time.Duration, job Job, opts ...Option) error {
+    return this.Dispatch(job, append([]Option{Delay(delay)}, opts...)...)
+}
+
+// 推送任务，同步驱动时直接执行并返回任务的错误
+func (this *Queue) DispatchContext(ctx context.Context, job Job, opts ...Option) error {
+    msg, err := this.NewMessage(job, opts...)
+    if err != nil {
+        return err
+    }
+
+    if this.IsSync() {
+        return this.runSync(ctx, job, msg)
+    }
+
+    if err := this.CheckTimeout(msg.Timeout); err != nil {
+        return err
+    }
+
+    return this.driver.Push(ctx, msg)
+}
+
+// 检测超时时间，需要小于驱动的保留时间，否则任务未完成时会被再次取出
+func (this *Queue) CheckTimeout(timeout time.Duration) error {
+    driver, ok := this.driver.(interfaces.RetryAfterDriver)
+    if !ok || timeout <= 0 {
+        return nil
+    }
+
+    if timeout >= driver.RetryAfter() {
+        return fmt.Errorf("%w: timeout %s, retry-after %s", ErrTimeoutTooLong, timeout, driver.RetryAfter())
+    }
+
+    return nil
+}
+
+// 生成消息
+func (this *Queue) NewMessage(job Job, opts ...Option) (*Message, error) {
+    if job == nil {
+        return nil, errors.New("queue: job is nil")
+    }
+
+    payload, err := json.Marshal(job)
+    if err != nil {
+        return nil, err
+    }
+
+    name := JobName(job)
+
+    // 推送的任务自动注册，方便同一进程执行
+    if !HasJob(name) {
+        RegisterJob(job)
+    }
+
+    now := time.Now()
+
+    msg := &Message{
+        ID:          uuid.ToUUIDString(),
+        Queue:       this.queue,
+        Job:         name,
+        Payload:     payload,
+        MaxAttempts: this.tries,
+        Backoff:     this.backoff,
+        Timeout:     this.timeout,
+        AvailableAt: now,
+        CreatedAt:   now,
+    }
+
+    for _, opt := range opts {
+        opt(msg)
+    }
+
+    if msg.Queue == "" {
+        msg.Queue = this.queue
+    }
+
+    return msg, nil
+}
+
+// 是否为同步驱动
+func (this *Queue) IsSync() bool {
+    if driver, ok := this.driver.(interfaces.SyncDriver); ok {
+        return driver.IsSync()
+    }
+
+    return false
+}
+
+// 取出任务
+func (this *Queue) Pop(ctx context.Context, queue string) (*Message, error) {
+    return this.driver.Pop(ctx, this.queueName(queue))
+}
+
+// 队列任务数量
+func (this *Queue) Size(ctx context.Context, queue string) (int64, error) {
+    return this.driver.Size(ctx, this.queueName(queue))
+}
+
+// 清空队列
+func (this *Queue) Clear(ctx context.Context, queue string) error {
+    return this.driver.Clear(ctx, this.queueName(queue))
+}
+
+// 执行取出的任务，失败时按重试间隔放回队列，次数用完时删除并交给失败处理
+func (this *Queue) Process(ctx context.Context, msg *Message) error {
+    job, err := NewJob(msg.Job, msg.Payload)
+    if err != nil {
+        this.driver.Delete(ctx, msg)
+        this.fail(ctx, msg, err)
+
+        return err
+    }
+
+    err = runJob(ctx, job, msg.Timeout)
+    if err == nil {
+        return this.driver.Delete(context.Background(), msg)
+    }
+
+    // 关闭时中断的任务放回队列，不计入执行次数
+    if ctx.Err() != nil {
+        msg.Attempts--
+        this.driver.Release(context.Background(), msg, 0)
+
+        return err
+    }
+
+    if msg.CanRetry() {
+        if rerr := this.driver.Release(ctx, msg, msg.BackoffFor(msg.Attempts)); rerr != nil {
+            return rerr
+        }
+
+        return err
+    }
+
+    this.driver.Delete(ctx, msg)
+    this.fail(ctx, msg, err)
+
+    return err
+}
+
+// 同步执行，忽略延迟时间，重试时等待重试间隔
+func (this *Queue) runSync(ctx context.Context, job Job, msg *Message) error {
+    for {
+        msg.Attempts++
+
+        err := runJob(ctx, job, msg.Timeout)
+        if err == nil {
+            return nil
+        }
+
+        if ctx.Err() != nil {
+            return err
+        }
+
+        if !msg.CanRetry() {
+            this.fail(ctx, msg, err)
+            return err
+        }
+
+        select {
+            case <-time.After(msg.BackoffFor(msg.Attempts)):
+            case <-ctx.Done():
+                return err
+        }
+    }
+}
+
+// 重新推送失败的任务，推送后从失败任务存储中删除
+func (this *Queue) Retry(ctx context.Context, job *FailedJob) error {
+    msg := &Message{}
+    if err := json.Unmarshal([]byte(job.Payload), msg); err != nil {
+        return err
+    }
+
+    msg.Attempts = 0
+    msg.AvailableAt = time.Now()
+
+    if err := this.driver.Push(ctx, msg); err != nil {
+        return err
+    }
+
+    if this.failedStore != nil {
+        if _, err := this.failedStore.Forget(ctx, job.ID); err != nil {
+            return err
+        }
+    }
+
+    return nil
+}
+
+// 失败处理，有失败任务存储时先保存
+func (this *Queue) fail(ctx context.Context, msg *Message, err error) {
+    if this.failedStore != nil {
+        if serr := this.failedStore.Save(context.Background(), this.newFailedJob(msg, err)); serr != nil {
+            logger.Default.
+                WithField("id", msg.ID).
+                WithError(serr).
+                Error("queue: save failed job failed")
+        }
+    }
+
+    if this.failed == nil {
+        return
+    }
+
+    // 失败处理出错时不影响后续任务
+    defer func() {
+        recover()
+    }()
+
+    this.failed(ctx, msg, err)
+}
+
+// 生成失败的任务
+func (this *Queue) newFailedJob(msg *Message, err error) *FailedJob {
+    payload, _ := json.Marshal(msg)
+
+    return &FailedJob{
+        ID:         msg.ID,
+        Connection: this.connection,
+        Queue:      msg.Queue,
+        Job:        msg.Job,
+        Payload:    string(payload),
+        Exception:  err.Error(),
+        Stack:      ErrorStack(err),
+        FailedAt:   time.Now(),
+    }
+}
+
+// 队列名称
+func (this *Queue) queueName(queue string) string {
+    if queue == "" {
+        return this.queue
+    }
+
+    return queue
+}
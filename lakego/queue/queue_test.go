@@ -0,0 +1,264 @@
+package queue
+
+import (
+    "time"
+    "errors"
+    "context"
+    "testing"
+    "reflect"
+    "sync/atomic"
+
+    "github.com/deatil/lakego-doak/lakego/queue/failed"
+    "github.com/deatil/lakego-doak/lakego/queue/driver/memory"
+)
+
+func assertT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+// 测试任务
+type testJob struct {
+    Name string `json:"name"`
+}
+
+func (this *testJob) Handle(ctx context.Context) error {
+    return nil
+}
+
+// 总是失败的任务
+type failJob struct {}
+
+func (this *failJob) Handle(ctx context.Context) error {
+    return errors.New("fail")
+}
+
+// 等待 ctx 结束的任务
+type waitJob struct {}
+
+func (this *waitJob) Handle(ctx context.Context) error {
+    <-ctx.Done()
+    return ctx.Err()
+}
+
+// 不处理 ctx 的任务
+type slowJob struct {}
+
+func (this *slowJob) Handle(ctx context.Context) error {
+    time.Sleep(50 * time.Millisecond)
+    return errors.New("slow")
+}
+
+// 执行完成的任务数量及被中断的任务数量
+var runningDone, runningCanceled int64
+
+// 执行一段时间的任务
+type runningJob struct {}
+
+func (this *runningJob) Handle(ctx context.Context) error {
+    select {
+        case <-ctx.Done():
+            atomic.AddInt64(&runningCanceled, 1)
+            return ctx.Err()
+        case <-time.After(50 * time.Millisecond):
+            atomic.AddInt64(&runningDone, 1)
+            return nil
+    }
+}
+
+// 使用内存驱动的队列
+func newTestQueue() (*Queue, *failed.Memory) {
+    store := failed.NewMemory()
+
+    q := New(memory.New()).
+        WithFailedStore(store).
+        WithFailedHandler(nil)
+
+    return q, store
+}
+
+func Test_DelayOrdering(t *testing.T) {
+    assert := assertT(t)
+
+    q, _ := newTestQueue()
+    ctx := context.Background()
+
+    now := time.Now()
+
+    q.Dispatch(&testJob{Name: "later"}, At(now.Add(-time.Second)))
+    q.Dispatch(&testJob{Name: "delayed"}, Delay(time.Hour))
+    q.Dispatch(&testJob{Name: "first"}, At(now.Add(-2 * time.Second)))
+    q.Dispatch(&testJob{Name: "mail"}, OnQueue("mail"))
+
+    size, _ := q.Size(ctx, "")
+    assert(size, int64(3), "Size")
+
+    names := make([]string, 0)
+    for {
+        msg, err := q.Pop(ctx, "")
+        assert(err, nil, "Pop error")
+        if msg == nil {
+            break
+        }
+
+        assert(msg.Attempts, 1, "Pop attempts")
+
+        job, _ := NewJob(msg.Job, msg.Payload)
+        names = append(names, job.(*testJob).Name)
+
+        assert(q.Process(ctx, msg), nil, "Process")
+    }
+
+    assert(names, []string{"first", "later"}, "Pop by available time")
+
+    size, _ = q.Size(ctx, "")
+    assert(size, int64(1), "Size delayed left")
+
+    msg, _ := q.Pop(ctx, "mail")
+    assert(msg != nil, true, "Pop other queue")
+}
+
+func Test_TriesExhausted(t *testing.T) {
+    assert := assertT(t)
+
+    q, store := newTestQueue()
+    ctx := context.Background()
+
+    var failedErr error
+    q.WithFailedHandler(func(ctx context.Context, msg *Message, err error) {
+        failedErr = err
+    })
+
+    q.Dispatch(&failJob{}, Tries(2), Backoff(0))
+
+    msg, _ := q.Pop(ctx, "")
+    assert(q.Process(ctx, msg).Error(), "fail", "Process first attempt")
+
+    jobs, _ := store.All(ctx)
+    assert(len(jobs), 0, "Failed store after first attempt")
+
+    msg, _ = q.Pop(ctx, "")
+    assert(msg.Attempts, 2, "Retry attempts")
+    assert(q.Process(ctx, msg).Error(), "fail", "Process last attempt")
+
+    jobs, _ = store.All(ctx)
+    assert(len(jobs), 1, "Failed store")
+    assert(jobs[0].ID, msg.ID, "Failed job id")
+    assert(jobs[0].Exception, "fail", "Failed job exception")
+    assert(failedErr.Error(), "fail", "Failed handler")
+
+    size, _ := q.Size(ctx, "")
+    assert(size, int64(0), "Size after failed")
+}
+
+func Test_JobNotRegistered(t *testing.T) {
+    assert := assertT(t)
+
+    q, store := newTestQueue()
+    ctx := context.Background()
+
+    msg, _ := q.NewMessage(&testJob{})
+    msg.Job = "missing.Job"
+    q.GetDriver().Push(ctx, msg)
+
+    msg, _ = q.Pop(ctx, "")
+    assert(errors.Is(q.Process(ctx, msg), ErrJobNotRegistered), true, "Process ErrJobNotRegistered")
+
+    jobs, _ := store.All(ctx)
+    assert(len(jobs), 1, "Failed store not registered")
+
+    size, _ := q.Size(ctx, "")
+    assert(size, int64(0), "Size not registered")
+}
+
+func Test_CancelRelease(t *testing.T) {
+    assert := assertT(t)
+
+    q, store := newTestQueue()
+
+    q.Dispatch(&waitJob{}, Tries(1))
+
+    msg, _ := q.Pop(context.Background(), "")
+
+    ctx, cancel := context.WithCancel(context.Background())
+    time.AfterFunc(10 * time.Millisecond, cancel)
+
+    assert(q.Process(ctx, msg), context.Canceled, "Process canceled")
+
+    jobs, _ := store.All(context.Background())
+    assert(len(jobs), 0, "Canceled not failed")
+
+    // 放回队列，不计入执行次数
+    msg, _ = q.Pop(context.Background(), "")
+    assert(msg != nil, true, "Canceled released")
+    assert(msg.Attempts, 1, "Canceled attempts not consumed")
+}
+
+func Test_Timeout(t *testing.T) {
+    assert := assertT(t)
+
+    q, _ := newTestQueue()
+    ctx := context.Background()
+
+    q.Dispatch(&slowJob{}, Timeout(10 * time.Millisecond), Tries(2))
+
+    msg, _ := q.Pop(ctx, "")
+
+    // 等待不处理 ctx 的任务返回后才放回队列
+    start := time.Now()
+    assert(q.Process(ctx, msg), ErrTimeout, "Process timeout")
+    assert(time.Since(start) >= 50 * time.Millisecond, true, "Process waits for job")
+
+    size, _ := q.Size(ctx, "")
+    assert(size, int64(1), "Timeout released")
+}
+
+// 带保留时间的驱动
+type retryAfterDriver struct {
+    *memory.Memory
+}
+
+func (this retryAfterDriver) RetryAfter() time.Duration {
+    return time.Minute
+}
+
+func Test_CheckTimeout(t *testing.T) {
+    assert := assertT(t)
+
+    q := New(retryAfterDriver{memory.New()}).WithFailedHandler(nil)
+
+    assert(q.CheckTimeout(0), nil, "CheckTimeout unlimited")
+    assert(q.CheckTimeout(30 * time.Second), nil, "CheckTimeout less")
+    assert(errors.Is(q.CheckTimeout(time.Minute), ErrTimeoutTooLong), true, "CheckTimeout equal")
+
+    err := q.Dispatch(&testJob{}, Timeout(2 * time.Minute))
+    assert(errors.Is(err, ErrTimeoutTooLong), true, "Dispatch timeout too long")
+
+    assert(New(memory.New()).CheckTimeout(time.Hour), nil, "CheckTimeout without retry-after")
+}
+
+func Test_WorkerStop(t *testing.T) {
+    assert := assertT(t)
+
+    q, _ := newTestQueue()
+
+    q.Dispatch(&runningJob{})
+    q.Dispatch(&runningJob{})
+
+    ctx, cancel := context.WithCancel(context.Background())
+    time.AfterFunc(10 * time.Millisecond, cancel)
+
+    worker := NewWorker(q).WithSleep(time.Millisecond)
+    worker.Run(ctx)
+
+    // 停止后不再取出任务，正在执行的任务不会被中断
+    assert(atomic.LoadInt64(&runningDone), int64(1), "Running job done")
+    assert(atomic.LoadInt64(&runningCanceled), int64(0), "Running job not canceled")
+    assert(worker.Processed(), int64(1), "Processed")
+
+    size, _ := q.Size(context.Background(), "")
+    assert(size, int64(1), "Size after stop")
+}
@@ -0,0 +1,256 @@
+package queue
+
+import (
+    "sync"
+    "time"
+    "context"
+    "sync/atomic"
+
+    "github.com/deatil/lakego-doak/lakego/facade/logger"
+)
+
+// 没有任务时的等待时间
+const DefaultSleep = time.Second
+
+/**
+ * 执行队列任务
+ *
+ * worker := queue.NewWorker(q, "high", "default").
+ *     WithConcurrency(4).
+ *     WithMaxJobs(1000).
+ *     WithMaxTime(time.Hour)
+ * worker.Run(ctx)
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Worker struct {
+    // 队列
+    queue *Queue
+
+    // 队列名称，按顺序优先取出
+    queues []string
+
+    // 没有任务时的等待时间
+    sleep time.Duration
+
+    // 并发数量
+    concurrency int
+
+    // 最多执行的任务数量，0 为不限制
+    maxJobs int64
+
+    // 最长运行时间，0 为不限制
+    maxTime time.Duration
+
+    // 已取出的任务数量
+    started int64
+
+    // 已执行的任务数量
+    processed int64
+}
+
+// 构造函数
+func NewWorker(q *Queue, queues ...string) *Worker {
+    if len(queues) == 0 {
+        queues = []string{q.GetQueue()}
+    }
+
+    return &Worker{
+        queue:  q,
+        queues:      queues,
+        sleep:       DefaultSleep,
+        concurrency: 1,
+    }
+}
+
+// 设置没有任务时的等待时间
+func (this *Worker) WithSleep(sleep time.Duration) *Worker {
+    if sleep > 0 {
+        this.sleep = sleep
+    }
+
+    return this
+}
+
+// 设置并发数量
+func (this *Worker) WithConcurrency(concurrency int) *Worker {
+    if concurrency > 0 {
+        this.concurrency = concurrency
+    }
+
+    return this
+}
+
+// 设置最多执行的任务数量
+func (this *Worker) WithMaxJobs(maxJobs int64) *Worker {
+    this.maxJobs = maxJobs
+
+    return this
+}
+
+// 设置最长运行时间
+func (this *Worker) WithMaxTime(maxTime time.Duration) *Worker {
+    this.maxTime = maxTime
+
+    return this
+}
+
+// 已执行的任务数量
+func (this *Worker) Processed() int64 {
+    return atomic.LoadInt64(&this.processed)
+}
+
+// 队列名称
+func (this *Worker) GetQueues() []string {
+    return this.queues
+}
+
+// 按队列顺序取出一个任务
+func (this *Worker) Next(ctx context.Context) (*Message, error) {
+    for _, name := range this.queues {
+        msg, err := this.queue.Pop(ctx, name)
+        if err != nil {
+            return nil, err
+        }
+
+        if msg != nil {
+            return msg, nil
+        }
+    }
+
+    return nil, nil
+}
+
+// 取出并执行一个任务，没有任务时返回 false
+func (this *Worker) RunNextJob(ctx context.Context) (bool, error) {
+    msg, err := this.Next(ctx)
+    if err != nil || msg == nil {
+        return false, err
+    }
+
+    return true, this.queue.Process(ctx, msg)
+}
+
+// 持续执行任务，ctx 结束、达到最多任务数量或者最长运行时间后不再取出任务，
+// 等待正在执行的任务完成后返回。正在执行的任务只保留 ctx 的值，不会被 ctx 中断
+func (this *Worker) Run(ctx context.Context) error {
+    runCtx := jobContext{ctx}
+
+    if this.maxTime > 0 {
+        var cancel context.CancelFunc
+        ctx, cancel = context.WithTimeout(ctx, this.maxTime)
+        defer cancel()
+    }
+
+    ctx, stop := context.WithCancel(ctx)
+    defer stop()
+
+    var wg sync.WaitGroup
+
+    wg.Add(this.concurrency)
+    for i := 0; i < this.concurrency; i++ {
+        go func() {
+            defer wg.Done()
+
+            this.loop(ctx, runCtx, stop)
+        }()
+    }
+
+    wg.Wait()
+
+    return nil
+}
+
+// 执行协程，ctx 控制取出任务，runCtx 传给正在执行的任务
+func (this *Worker) loop(ctx context.Context, runCtx context.Context, stop context.CancelFunc) {
+    for {
+        if ctx.Err() != nil {
+            return
+        }
+
+        // 其他协程的任务达到最多任务数量时等待
+        if !this.acquire() {
+            if !this.wait(ctx) {
+                return
+            }
+
+            continue
+        }
+
+        msg, err := this.Next(ctx)
+        if err != nil {
+            logger.Default.WithError(err).Error("queue: pop job failed")
+        }
+
+        if err != nil || msg == nil {
+            this.release()
+
+            if !this.wait(ctx) {
+                return
+            }
+
+            continue
+        }
+
+        // 停止、达到最多任务数量或者最长运行时间时不中断正在执行的任务
+        this.queue.Process(runCtx, msg)
+
+        processed := atomic.AddInt64(&this.processed, 1)
+        if this.maxJobs > 0 && processed >= this.maxJobs {
+            stop()
+        }
+    }
+}
+
+// 等待，ctx 结束时返回 false
+func (this *Worker) wait(ctx context.Context) bool {
+    select {
+        case <-ctx.Done():
+            return false
+        case <-time.After(this.sleep):
+            return true
+    }
+}
+
+// 占用任务数量
+func (this *Worker) acquire() bool {
+    if this.maxJobs <= 0 {
+        return true
+    }
+
+    if atomic.AddInt64(&this.started, 1) > this.maxJobs {
+        atomic.AddInt64(&this.started, -1)
+        return false
+    }
+
+    return true
+}
+
+// 释放任务数量
+func (this *Worker) release() {
+    if this.maxJobs > 0 {
+        atomic.AddInt64(&this.started, -1)
+    }
+}
+
+// 任务上下文，只保留值，不继承截止时间及取消
+type jobContext struct {
+    parent context.Context
+}
+
+func (jobContext) Deadline() (time.Time, bool) {
+    return time.Time{}, false
+}
+
+func (jobContext) Done() <-chan struct{} {
+    return nil
+}
+
+func (jobContext) Err() error {
+    return nil
+}
+
+func (this jobContext) Value(key any) any {
+    return this.parent.Value(key)
+}
@@ -1,5 +1,10 @@
 package router
 
+import (
+    "reflect"
+    "runtime"
+)
+
 var defaultMiddleware = NewMiddleware()
 
 // 默认
@@ -162,3 +167,21 @@ func (this *Middleware) GetGlobalMiddlewareList() (middleware []any) {
     return this.GetMiddlewareList(this.globalName)
 }
 
+// 函数名称
+func FuncName(fn any) string {
+    value := reflect.ValueOf(fn)
+    if value.Kind() != reflect.Func {
+        return reflect.TypeOf(fn).String()
+    }
+
+    return funcNameForPC(value.Pointer())
+}
+
+// 函数地址对应的名称
+func funcNameForPC(pc uintptr) string {
+    if f := runtime.FuncForPC(pc); f != nil {
+        return f.Name()
+    }
+
+    return ""
+}
@@ -50,12 +50,19 @@ type RouteName struct {
 
     // 列表
     routes RouterInfoMap
+
+    // 请求方式和路径对应的别名
+    index map[string]string
+
+    // 生成完整链接使用的域名
+    baseURL string
 }
 
 // 单例
 func NewName() *RouteName {
     return &RouteName{
         routes: make(RouterInfoMap),
+        index:  make(map[string]string),
     }
 }
 
@@ -64,7 +71,7 @@ func (this *RouteName) SetRouteName(name string, route RouterInfo) *RouteName {
     this.mu.Lock()
     defer this.mu.Unlock()
 
-    this.routes[name] = route
+    this.setRoute(name, route)
 
     return this
 }
@@ -76,10 +83,10 @@ func (this *RouteName) SetName(name string) *RouteName {
 
     route := DefaultRoute().GetLastRoute()
 
-    this.routes[name] = RouterInfo{
+    this.setRoute(name, RouterInfo{
         route,
         name,
-    }
+    })
 
     return this
 }
@@ -97,3 +104,29 @@ func (this *RouteName) GetRoute(name string) RouterInfo {
 
     return RouterInfo{}
 }
+
+// 根据请求方式和路径获取别名
+func (this *RouteName) GetNameByRoute(method string, path string) string {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    return this.index[routeKey(method, path)]
+}
+
+// 保存别名并更新索引
+func (this *RouteName) setRoute(name string, route RouterInfo) {
+    if old, ok := this.routes[name]; ok {
+        key := routeKey(old.Method, old.Path)
+        if this.index[key] == name {
+            delete(this.index, key)
+        }
+    }
+
+    this.routes[name] = route
+    this.index[routeKey(route.Method, route.Path)] = name
+}
+
+// 索引键名
+func routeKey(method string, path string) string {
+    return method + " " + path
+}
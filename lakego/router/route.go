@@ -1,5 +1,9 @@
 package router
 
+import (
+    "reflect"
+)
+
 var defaultRoute = NewRoute()
 
 func DefaultRoute() *Route {
@@ -68,3 +72,83 @@ func (this *Route) GetLastRoute() RouteInfo {
 
     return routes[len(routes) - 1]
 }
+
+// 路由注册的处理链，键名为请求方式及路径
+// 包含注册时的全局中间件、分组中间件及处理函数，有别名的中间件显示别名
+func (this *Route) GetHandlerChains() map[string][]string {
+    chains := make(map[string][]string)
+    if this.routeEngine == nil {
+        return chains
+    }
+
+    // 中间件别名，同一方法生成的中间件函数地址相同
+    aliases := make(map[uintptr]string)
+    for name, middleware := range DefaultMiddleware().GetAlias().GetAll() {
+        value := reflect.ValueOf(middleware)
+        if value.Kind() == reflect.Func {
+            aliases[value.Pointer()] = name
+        }
+    }
+
+    // gin 没有公开处理链，从路由树读取注册时保存的处理链
+    trees := reflect.ValueOf(this.routeEngine).Elem().FieldByName("trees")
+    if !trees.IsValid() || trees.Kind() != reflect.Slice {
+        return chains
+    }
+
+    for i := 0; i < trees.Len(); i++ {
+        method := trees.Index(i).FieldByName("method")
+        root := trees.Index(i).FieldByName("root")
+        if method.Kind() != reflect.String || root.Kind() != reflect.Ptr {
+            continue
+        }
+
+        walkRouteNode(chains, aliases, method.String(), "", root)
+    }
+
+    return chains
+}
+
+// 路由的处理链，最后一个为处理函数
+func (this *Route) GetHandlerChain(method string, path string) []string {
+    return this.GetHandlerChains()[routeKey(method, path)]
+}
+
+// 遍历路由树节点
+func walkRouteNode(chains map[string][]string, aliases map[uintptr]string, method string, path string, node reflect.Value) {
+    if node.IsNil() {
+        return
+    }
+
+    n := node.Elem()
+
+    nodePath := n.FieldByName("path")
+    handlers := n.FieldByName("handlers")
+    children := n.FieldByName("children")
+    if nodePath.Kind() != reflect.String ||
+        handlers.Kind() != reflect.Slice ||
+        children.Kind() != reflect.Slice {
+        return
+    }
+
+    path += nodePath.String()
+
+    if handlers.Len() > 0 {
+        names := make([]string, 0, handlers.Len())
+        for i := 0; i < handlers.Len(); i++ {
+            pc := handlers.Index(i).Pointer()
+
+            if name, ok := aliases[pc]; ok {
+                names = append(names, name)
+            } else {
+                names = append(names, funcNameForPC(pc))
+            }
+        }
+
+        chains[routeKey(method, path)] = names
+    }
+
+    for i := 0; i < children.Len(); i++ {
+        walkRouteNode(chains, aliases, method, path, children.Index(i))
+    }
+}
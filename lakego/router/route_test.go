@@ -0,0 +1,31 @@
+package router
+
+import (
+    "testing"
+)
+
+func testAuth(ctx *Context) {}
+
+func testHandler(ctx *Context) {}
+
+func Test_GetHandlerChains(t *testing.T) {
+    assert := assertT(t)
+
+    engine := New()
+
+    route := NewRoute()
+    route.With(engine)
+
+    DefaultMiddleware().AliasMiddleware("test.auth", HandlerFunc(testAuth))
+    defer DefaultMiddleware().GetAlias().Remove("test.auth")
+
+    group := engine.Group("/admin", testAuth)
+    group.GET("/user/:id", testHandler)
+    engine.GET("/ping", testHandler)
+
+    handler := FuncName(testHandler)
+
+    assert(route.GetHandlerChain("GET", "/admin/user/:id"), []string{"test.auth", handler}, "GetHandlerChain group")
+    assert(route.GetHandlerChain("GET", "/ping"), []string{handler}, "GetHandlerChain")
+    assert(len(route.GetHandlerChains()), len(engine.Routes()), "GetHandlerChains count")
+}
@@ -0,0 +1,165 @@
+package router
+
+import (
+    "fmt"
+    "errors"
+    "strings"
+    "net/url"
+)
+
+var (
+    // 别名不存在
+    ErrRouteNameNotFound = errors.New("router: route name not found")
+
+    // 缺少参数
+    ErrRouteParamMissing = errors.New("router: route param missing")
+)
+
+// 链接参数
+type (
+    // 路由参数
+    URLParams = map[string]any
+
+    // 查询参数
+    URLQuery = map[string]any
+)
+
+// 设置生成完整链接使用的域名，比如 https://example.com
+func (this *RouteName) WithBaseURL(baseURL string) *RouteName {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.baseURL = strings.TrimRight(baseURL, "/")
+
+    return this
+}
+
+// 生成完整链接使用的域名
+func (this *RouteName) GetBaseURL() string {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    return this.baseURL
+}
+
+// 根据别名生成链接
+// :param 参数必须传入，*wildcard 参数可以为空，路径没有使用的参数作为查询参数
+// router.URL("admin.user.edit", router.URLParams{"id": 5}, router.URLQuery{"tab": "info"})
+func (this *RouteName) URL(name string, params URLParams, query ...URLQuery) (string, error) {
+    this.mu.RLock()
+    route, ok := this.routes[name]
+    this.mu.RUnlock()
+
+    if !ok {
+        return "", fmt.Errorf("%w: %s", ErrRouteNameNotFound, name)
+    }
+
+    used := make(map[string]bool)
+
+    segments := strings.Split(route.Path, "/")
+    for i, segment := range segments {
+        if segment == "" {
+            continue
+        }
+
+        switch segment[0] {
+            case ':':
+                key := segment[1:]
+
+                value, ok := params[key]
+                if !ok || fmt.Sprint(value) == "" {
+                    return "", fmt.Errorf("%w: %s in route %s", ErrRouteParamMissing, key, name)
+                }
+
+                used[key] = true
+                segments[i] = url.PathEscape(fmt.Sprint(value))
+
+            case '*':
+                key := segment[1:]
+
+                used[key] = true
+                segments[i] = escapeWildcard(params[key])
+        }
+    }
+
+    path := strings.Join(segments, "/")
+    if path == "" {
+        path = "/"
+    }
+
+    values := url.Values{}
+    for key, value := range params {
+        if !used[key] {
+            addQueryValue(values, key, value)
+        }
+    }
+
+    for _, q := range query {
+        for key, value := range q {
+            addQueryValue(values, key, value)
+        }
+    }
+
+    if len(values) > 0 {
+        path += "?" + values.Encode()
+    }
+
+    return path, nil
+}
+
+// 根据别名生成带域名的完整链接，没有设置域名时返回路径
+func (this *RouteName) AbsoluteURL(name string, params URLParams, query ...URLQuery) (string, error) {
+    path, err := this.URL(name, params, query...)
+    if err != nil {
+        return "", err
+    }
+
+    return this.GetBaseURL() + path, nil
+}
+
+// 根据别名生成链接
+func URL(name string, params URLParams, query ...URLQuery) (string, error) {
+    return DefaultName().URL(name, params, query...)
+}
+
+// 根据别名生成带域名的完整链接
+func AbsoluteURL(name string, params URLParams, query ...URLQuery) (string, error) {
+    return DefaultName().AbsoluteURL(name, params, query...)
+}
+
+// 设置生成完整链接使用的域名
+func SetBaseURL(baseURL string) {
+    DefaultName().WithBaseURL(baseURL)
+}
+
+// 通配参数，保留路径分隔符
+func escapeWildcard(value any) string {
+    if value == nil {
+        return ""
+    }
+
+    parts := strings.Split(strings.TrimLeft(fmt.Sprint(value), "/"), "/")
+    for i, part := range parts {
+        parts[i] = url.PathEscape(part)
+    }
+
+    return strings.Join(parts, "/")
+}
+
+// 添加查询参数，切片添加为多个值
+func addQueryValue(values url.Values, key string, value any) {
+    switch v := value.(type) {
+        case []string:
+            for _, item := range v {
+                values.Add(key, item)
+            }
+
+        case []any:
+            for _, item := range v {
+                values.Add(key, fmt.Sprint(item))
+            }
+
+        default:
+            values.Add(key, fmt.Sprint(value))
+    }
+}
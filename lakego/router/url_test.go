@@ -0,0 +1,83 @@
+package router
+
+import (
+    "errors"
+    "testing"
+    "reflect"
+)
+
+func assertT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func testRouteName() *RouteName {
+    name := NewName()
+
+    name.SetRouteName("user.edit", RouterInfo{RouteInfo{Method: "GET", Path: "/admin/user/:id/edit"}, "user.edit"})
+    name.SetRouteName("files", RouterInfo{RouteInfo{Method: "GET", Path: "/files/*filepath"}, "files"})
+    name.SetRouteName("home", RouterInfo{RouteInfo{Method: "GET", Path: "/"}, "home"})
+
+    return name
+}
+
+func Test_URL(t *testing.T) {
+    assert := assertT(t)
+
+    name := testRouteName()
+
+    link, err := name.URL("user.edit", URLParams{"id": 5})
+    assert(err, nil, "URL error")
+    assert(link, "/admin/user/5/edit", "URL param")
+
+    link, _ = name.URL("user.edit", URLParams{"id": "a b/c"})
+    assert(link, "/admin/user/a%20b%2Fc/edit", "URL escape param")
+
+    link, _ = name.URL("user.edit", URLParams{"id": 5, "tab": "info"}, URLQuery{"tags": []string{"a", "b"}})
+    assert(link, "/admin/user/5/edit?tab=info&tags=a&tags=b", "URL query")
+
+    link, _ = name.URL("home", nil)
+    assert(link, "/", "URL root")
+
+    _, err = name.URL("user.edit", nil)
+    assert(errors.Is(err, ErrRouteParamMissing), true, "URL missing param")
+
+    _, err = name.URL("user.edit", URLParams{"id": ""})
+    assert(errors.Is(err, ErrRouteParamMissing), true, "URL empty param")
+
+    _, err = name.URL("missing", nil)
+    assert(errors.Is(err, ErrRouteNameNotFound), true, "URL missing name")
+}
+
+func Test_URLWildcard(t *testing.T) {
+    assert := assertT(t)
+
+    name := testRouteName()
+
+    link, _ := name.URL("files", URLParams{"filepath": "/css/app v1.css"})
+    assert(link, "/files/css/app%20v1.css", "URL wildcard with slashes")
+
+    link, _ = name.URL("files", nil)
+    assert(link, "/files/", "URL empty wildcard")
+
+    assert(escapeWildcard("a/b c/d?e"), "a/b%20c/d%3Fe", "escapeWildcard")
+    assert(escapeWildcard("//a"), "a", "escapeWildcard leading slashes")
+    assert(escapeWildcard(nil), "", "escapeWildcard nil")
+}
+
+func Test_AbsoluteURL(t *testing.T) {
+    assert := assertT(t)
+
+    name := testRouteName()
+
+    link, _ := name.AbsoluteURL("user.edit", URLParams{"id": 5})
+    assert(link, "/admin/user/5/edit", "AbsoluteURL without base")
+
+    name.WithBaseURL("https://example.com/")
+
+    link, _ = name.AbsoluteURL("user.edit", URLParams{"id": 5})
+    assert(link, "https://example.com/admin/user/5/edit", "AbsoluteURL")
+}
@@ -2,6 +2,8 @@ package schedule
 
 import (
     "github.com/robfig/cron/v3"
+
+    "github.com/deatil/lakego-doak/lakego/schedule/interfaces"
 )
 
 var (
@@ -53,6 +55,9 @@ type (
     CronEntry   = cron.Entry
 
     CronLogger  = cron.Logger
+
+    History      = interfaces.History
+    HistoryStore = interfaces.HistoryStore
 )
 
 // 接口
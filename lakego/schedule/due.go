@@ -0,0 +1,108 @@
+package schedule
+
+import (
+    "time"
+
+    "github.com/robfig/cron/v3"
+)
+
+// 计划时间解析，和 WithSeconds 一致
+var specParser = cron.NewParser(
+    cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor,
+)
+
+/**
+ * 需要执行的任务的执行结果
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type DueRun struct {
+    // 任务
+    Entry *Entry
+
+    // 执行错误，不满足执行条件时为 ErrFiltered，获取锁失败时为 ErrLocked
+    // 任务设置错误时为设置的错误，不会执行
+    Err error
+}
+
+// 解析任务的计划时间
+func (this *Schedule) ParseSpec(entry *Entry) (ISchedule, error) {
+    if entry.Spec == "" {
+        return entry.Schedule, nil
+    }
+
+    return specParser.Parse(entry.Spec)
+}
+
+// 任务在 t 之后的下次执行时间，解析失败时为空
+func (this *Schedule) NextRun(entry *Entry, t time.Time) time.Time {
+    sched, err := this.ParseSpec(entry)
+    if err != nil || sched == nil {
+        return time.Time{}
+    }
+
+    return sched.Next(t.In(this.CronLocation()))
+}
+
+// 任务在 t 所在的分钟内是否需要执行
+func (this *Schedule) IsDue(entry *Entry, t time.Time) bool {
+    start := t.In(this.CronLocation()).Truncate(time.Minute)
+
+    next := this.NextRun(entry, start.Add(-time.Nanosecond))
+    if next.IsZero() {
+        return false
+    }
+
+    return next.Before(start.Add(time.Minute))
+}
+
+// t 所在的分钟内需要执行的任务
+func (this *Schedule) DueEntries(t time.Time) []*Entry {
+    entries := make([]*Entry, 0)
+
+    for _, entry := range this.entries {
+        if entry.Cmd != nil && this.IsDue(entry, t) {
+            entries = append(entries, entry)
+        }
+    }
+
+    return entries
+}
+
+// 依次执行 t 所在的分钟内需要执行的任务，返回全部需要执行的任务及执行结果
+// 后台执行的任务需要调用 Wait 等待完成后才有执行结果
+func (this *Schedule) RunDue(t time.Time) []*DueRun {
+    runs := make([]*DueRun, 0)
+
+    for _, entry := range this.DueEntries(t) {
+        run := &DueRun{
+            Entry: entry,
+        }
+
+        runs = append(runs, run)
+
+        if err := entry.validate(); err != nil {
+            run.Err = err
+            continue
+        }
+
+        if !entry.FiltersPass() {
+            run.Err = ErrFiltered
+
+            this.saveFiltered(entry)
+            continue
+        }
+
+        this.dispatch(entry, t.Truncate(time.Minute), func(err error) {
+            run.Err = err
+        })
+    }
+
+    return runs
+}
+
+// 等待后台执行的任务完成
+func (this *Schedule) Wait() {
+    this.wg.Wait()
+}
@@ -0,0 +1,114 @@
+package schedule
+
+import (
+    "time"
+    "errors"
+    "context"
+    "testing"
+    "reflect"
+
+    "github.com/deatil/lakego-doak/lakego/schedule/history"
+)
+
+func assertT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+func Test_IsDue(t *testing.T) {
+    assert := assertT(t)
+
+    s := New().WithCron(NewCron(WithSeconds(), WithLocation(time.UTC)))
+
+    everyFive := NewEntry().Cron("0 */5 * * * *")
+    daily := NewEntry().Cron("0 30 3 * * *")
+    everySecond := NewEntry().Cron("* * * * * *")
+    halfMinute := NewEntry().Cron("30 * * * * *")
+    invalid := NewEntry().Cron("bad spec")
+
+    now := time.Date(2026, 10, 19, 10, 5, 42, 0, time.UTC)
+
+    assert(s.IsDue(everyFive, now), true, "IsDue every five minutes")
+    assert(s.IsDue(everyFive, now.Add(time.Minute)), false, "IsDue every five minutes next minute")
+    assert(s.IsDue(daily, now), false, "IsDue daily")
+    assert(s.IsDue(daily, time.Date(2026, 10, 19, 3, 30, 59, 0, time.UTC)), true, "IsDue daily at time")
+    assert(s.IsDue(everySecond, now), true, "IsDue every second")
+    assert(s.IsDue(halfMinute, time.Date(2026, 10, 19, 10, 5, 0, 0, time.UTC)), true, "IsDue seconds in minute")
+    assert(s.IsDue(invalid, now), false, "IsDue invalid spec")
+
+    // 使用任务时区判断
+    local := time.FixedZone("UTC+8", 8 * 3600)
+    assert(s.IsDue(daily, time.Date(2026, 10, 19, 11, 30, 0, 0, local)), true, "IsDue location")
+
+    // 使用 Schedule 结构体
+    every := NewEntry().AddSchedule(Every(time.Minute), IFuncJob(func() {}))
+    assert(s.IsDue(every, now), true, "IsDue schedule")
+}
+
+func Test_DueEntries(t *testing.T) {
+    assert := assertT(t)
+
+    s := New().WithCron(NewCron(WithSeconds(), WithLocation(time.UTC)))
+
+    s.AddFunc(func() {}).WithName("every-five").Cron("0 */5 * * * *")
+    s.AddFunc(func() {}).WithName("daily").Cron("0 30 3 * * *")
+    s.WithEntry(NewEntry().WithName("no-cmd").Cron("* * * * * *"))
+
+    names := make([]string, 0)
+    for _, entry := range s.DueEntries(time.Date(2026, 10, 19, 10, 5, 0, 0, time.UTC)) {
+        names = append(names, entry.Name)
+    }
+
+    assert(names, []string{"every-five"}, "DueEntries")
+}
+
+func Test_RunDue(t *testing.T) {
+    assert := assertT(t)
+
+    s := New().
+        WithCron(NewCron(WithSeconds(), WithLocation(time.UTC))).
+        WithHistoryStore(history.NewMemory(10))
+
+    failed := errors.New("failed")
+
+    s.AddFunc(func() {}).WithName("success").Cron("0 * * * * *")
+    s.AddFunc(func() error {
+        return failed
+    }).WithName("failed").Cron("0 * * * * *")
+    s.AddFunc(func() {}).WithName("filtered").Cron("0 * * * * *").When(func() bool {
+        return false
+    })
+    s.AddFunc(func() error {
+        return failed
+    }).WithName("background").Cron("0 * * * * *").RunInBackground()
+
+    runs := s.RunDue(time.Date(2026, 10, 19, 10, 5, 0, 0, time.UTC))
+    s.Wait()
+
+    results := make(map[string]error)
+    for _, run := range runs {
+        results[run.Entry.Name] = run.Err
+    }
+
+    assert(len(runs), 4, "RunDue runs")
+    assert(results["success"], nil, "RunDue success")
+    assert(results["failed"], failed, "RunDue failed")
+    assert(results["filtered"], ErrFiltered, "RunDue filtered")
+    assert(results["background"], failed, "RunDue background")
+}
+
+func Test_TimeoutRequiresContext(t *testing.T) {
+    assert := assertT(t)
+
+    assert(NewEntry().WithCmd(func() {}).validate(), nil, "validate func without timeout")
+    assert(NewEntry().WithCmd(func(context.Context) {}).Timeout(time.Second).validate(), nil, "validate context func timeout")
+    assert(NewEntry().WithCmd(&CommandJob{}).Timeout(time.Second).validate(), nil, "validate output job timeout")
+
+    assert(NewEntry().WithCmd(func() {}).Timeout(time.Second).validate() != nil, true, "validate func timeout")
+    assert(NewEntry().WithCmd(func() error { return nil }).Timeout(time.Second).validate() != nil, true, "validate func error timeout")
+    assert(NewEntry().WithCmd(IFuncJob(func() {})).Timeout(time.Second).validate() != nil, true, "validate job timeout")
+    assert(NewEntry().WithCmd("bad").validate() != nil, true, "validate unsupported")
+}
@@ -1,7 +1,11 @@
 package schedule
 
 import (
+    "time"
     "strings"
+
+    "github.com/deatil/lakego-doak/lakego/cache"
+    cacheFacade "github.com/deatil/lakego-doak/lakego/facade/cache"
 )
 
 /**
@@ -22,6 +26,57 @@ type Entry struct {
 
     // 当前任务名称
     Name string
+
+    // 所属计划任务
+    schedule *Schedule
+
+    // 防止重叠执行
+    withoutOverlapping bool
+
+    // 防重叠锁过期时间
+    overlapExpire time.Duration
+
+    // 只在一个服务器执行
+    onOneServer bool
+
+    // 后台执行
+    background bool
+
+    // 超时时间
+    timeout time.Duration
+
+    // 输出保存的文件
+    outputPath string
+
+    // 是否追加到输出文件
+    outputAppend bool
+
+    // 输出保存的日志通道
+    outputChannel string
+
+    // 执行条件
+    filters []func() bool
+
+    // 跳过条件
+    rejects []func() bool
+
+    // 执行环境
+    environments []string
+
+    // 执行前
+    beforeCallbacks []func()
+
+    // 执行后
+    afterCallbacks []func()
+
+    // 执行成功
+    successCallbacks []func()
+
+    // 执行失败
+    failureCallbacks []func(error)
+
+    // 设置错误，添加任务时返回
+    err error
 }
 
 // 构造函数
@@ -31,6 +86,11 @@ func NewEntry() *Entry {
     }
 }
 
+// 设置任务时的错误，比如时间段格式错误
+func (this *Entry) Err() error {
+    return this.err
+}
+
 // 设置计划时间
 func (this *Entry) Cron(spec string) *Entry {
     this.Spec = spec
@@ -59,8 +119,120 @@ func (this *Entry) WithName(name string) *Entry {
     return this
 }
 
-// 函数
-func (this *Entry) AddFunc(cmd func()) *Entry {
+// 防止重叠执行，上次执行未完成时跳过本次执行
+// expire 为锁的过期时间，防止进程异常退出后锁一直存在，默认 24 小时
+func (this *Entry) WithoutOverlapping(expire ...time.Duration) *Entry {
+    this.withoutOverlapping = true
+    this.overlapExpire = DefaultOverlapExpire
+
+    if len(expire) > 0 && expire[0] > 0 {
+        this.overlapExpire = expire[0]
+    }
+
+    return this
+}
+
+// 多个服务器运行计划任务时，同一计划时间只在一个服务器执行
+// 需要使用多个服务器共享的缓存，比如 redis
+func (this *Entry) OnOneServer() *Entry {
+    this.onOneServer = true
+
+    return this
+}
+
+// 后台执行，触发时不等待任务完成
+// 默认阻塞执行，schedule:run 等依次执行任务时会等待任务完成
+func (this *Entry) RunInBackground() *Entry {
+    this.background = true
+
+    return this
+}
+
+// 是否后台执行
+func (this *Entry) IsBackground() bool {
+    return this.background
+}
+
+// 是否防止重叠执行
+func (this *Entry) IsWithoutOverlapping() bool {
+    return this.withoutOverlapping
+}
+
+// 是否只在一个服务器执行
+func (this *Entry) IsOnOneServer() bool {
+    return this.onOneServer
+}
+
+// 执行前回调
+func (this *Entry) Before(fn func()) *Entry {
+    this.beforeCallbacks = append(this.beforeCallbacks, fn)
+
+    return this
+}
+
+// 执行后回调，成功及失败都会调用
+func (this *Entry) After(fn func()) *Entry {
+    this.afterCallbacks = append(this.afterCallbacks, fn)
+
+    return this
+}
+
+// 执行成功回调
+func (this *Entry) OnSuccess(fn func()) *Entry {
+    this.successCallbacks = append(this.successCallbacks, fn)
+
+    return this
+}
+
+// 执行失败回调
+func (this *Entry) OnFailure(fn func(error)) *Entry {
+    this.failureCallbacks = append(this.failureCallbacks, fn)
+
+    return this
+}
+
+// 超时时间，超时后取消任务的 context，外部程序超时后会被结束
+// 只支持使用 context 的任务，func() 及 IJob 任务设置超时时间时添加失败
+func (this *Entry) Timeout(timeout time.Duration) *Entry {
+    this.timeout = timeout
+
+    return this
+}
+
+// 输出保存到文件，覆盖原有内容
+func (this *Entry) SendOutputTo(path string) *Entry {
+    this.outputPath = path
+    this.outputAppend = false
+
+    return this
+}
+
+// 输出追加到文件
+func (this *Entry) AppendOutputTo(path string) *Entry {
+    this.outputPath = path
+    this.outputAppend = true
+
+    return this
+}
+
+// 输出记录到日志通道
+func (this *Entry) SendOutputToLog(channel string) *Entry {
+    this.outputChannel = channel
+
+    return this
+}
+
+// 锁使用的缓存
+func (this *Entry) getCache() *cache.Cache {
+    if this.schedule != nil {
+        return this.schedule.GetCache()
+    }
+
+    return cacheFacade.Default
+}
+
+// 函数，支持 func(), func() error, func(context.Context) 及 func(context.Context) error
+func (this *Entry) AddFunc(cmd any) *Entry {
     return this.WithCmd(cmd)
 }
 
@@ -0,0 +1,126 @@
+package schedule
+
+import (
+    "fmt"
+    "time"
+    "strings"
+)
+
+// 执行条件，返回 true 时执行
+func (this *Entry) When(fn func() bool) *Entry {
+    this.filters = append(this.filters, fn)
+
+    return this
+}
+
+// 跳过条件，返回 true 时跳过
+func (this *Entry) Skip(fn func() bool) *Entry {
+    this.rejects = append(this.rejects, fn)
+
+    return this
+}
+
+// 只在时间段内执行，支持跨天，比如 Between("22:00", "06:00")
+func (this *Entry) Between(start string, end string) *Entry {
+    return this.When(this.inTimeInterval(start, end))
+}
+
+// 不在时间段内执行
+func (this *Entry) UnlessBetween(start string, end string) *Entry {
+    return this.Skip(this.inTimeInterval(start, end))
+}
+
+// 只在指定环境执行，环境为 server 配置的 mode
+func (this *Entry) Environments(envs ...string) *Entry {
+    this.environments = append(this.environments, envs...)
+
+    return this
+}
+
+// 是否满足执行条件
+func (this *Entry) FiltersPass() bool {
+    if len(this.environments) > 0 && !this.runsInEnvironment(this.getEnvironment()) {
+        return false
+    }
+
+    for _, fn := range this.filters {
+        if !fn() {
+            return false
+        }
+    }
+
+    for _, fn := range this.rejects {
+        if fn() {
+            return false
+        }
+    }
+
+    return true
+}
+
+// 是否设置了执行条件
+func (this *Entry) hasFilters() bool {
+    return len(this.environments) > 0 ||
+        len(this.filters) > 0 ||
+        len(this.rejects) > 0
+}
+
+// 是否在环境列表中
+func (this *Entry) runsInEnvironment(env string) bool {
+    for _, e := range this.environments {
+        if e == env {
+            return true
+        }
+    }
+
+    return false
+}
+
+// 当前环境
+func (this *Entry) getEnvironment() string {
+    if this.schedule != nil {
+        return this.schedule.GetEnvironment()
+    }
+
+    return defaultEnvironment()
+}
+
+// 当前时间是否在时间段内，时间格式错误时记录错误，添加任务时返回
+func (this *Entry) inTimeInterval(start string, end string) func() bool {
+    startMinute, err := parseClock(start)
+    if err != nil && this.err == nil {
+        this.err = err
+    }
+
+    endMinute, err := parseClock(end)
+    if err != nil && this.err == nil {
+        this.err = err
+    }
+
+    return func() bool {
+
+        now := time.Now()
+        if this.schedule != nil {
+            now = now.In(this.schedule.CronLocation())
+        }
+
+        minute := now.Hour() * 60 + now.Minute()
+
+        // 跨天
+        if endMinute < startMinute {
+            return minute >= startMinute || minute <= endMinute
+        }
+
+        return minute >= startMinute && minute <= endMinute
+    }
+}
+
+// 解析时间，返回当天的分钟数
+func parseClock(clock string) (int, error) {
+    t, err := time.Parse("15:04", strings.TrimSpace(clock))
+    if err != nil {
+        return 0, fmt.Errorf("schedule: invalid time %q, want HH:MM", clock)
+    }
+
+    return t.Hour() * 60 + t.Minute(), nil
+}
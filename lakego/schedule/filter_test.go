@@ -0,0 +1,32 @@
+package schedule
+
+import (
+    "time"
+    "testing"
+)
+
+func Test_BetweenInvalid(t *testing.T) {
+    assert := assertT(t)
+
+    entry := NewEntry().Between("22:00", "06:00")
+    assert(entry.Err(), nil, "Between valid")
+
+    entry = NewEntry().Between("25:00", "06:00")
+    assert(entry.Err() != nil, true, "Between invalid start")
+
+    entry = NewEntry().UnlessBetween("09:00", "9点")
+    assert(entry.Err() != nil, true, "UnlessBetween invalid end")
+
+    // 设置错误的任务不会执行
+    called := false
+
+    s := New().WithCron(NewCron(WithSeconds(), WithLocation(time.UTC)))
+    s.AddFunc(func() {
+        called = true
+    }).WithName("invalid").Cron("* * * * * *").Between("bad", "06:00")
+
+    runs := s.RunDue(time.Now())
+    assert(len(runs), 1, "RunDue invalid")
+    assert(runs[0].Err != nil && runs[0].Err == runs[0].Entry.Err(), true, "RunDue invalid error")
+    assert(called, false, "RunDue invalid not run")
+}
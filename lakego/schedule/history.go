@@ -0,0 +1,225 @@
+package schedule
+
+import (
+    "os"
+    "time"
+    "errors"
+    "context"
+
+    "github.com/deatil/lakego-doak/lakego/facade"
+    "github.com/deatil/lakego-doak/lakego/schedule/interfaces"
+    scheduleFacade "github.com/deatil/lakego-doak/lakego/facade/schedule"
+)
+
+// 默认错过执行的宽限时间
+const DefaultMissedGrace = time.Minute
+
+/**
+ * 错过执行的任务
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Missed struct {
+    // 任务
+    Entry *Entry
+
+    // 最后一次执行记录
+    Last *History
+
+    // 应该执行的时间
+    Expected time.Time
+}
+
+// 设置执行记录存储
+func (this *Schedule) WithHistoryStore(store HistoryStore) *Schedule {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    this.historyStore = store
+
+    return this
+}
+
+// 执行记录存储，未设置时使用 server 配置的 schedule.history，没有配置时为 nil
+func (this *Schedule) GetHistoryStore() HistoryStore {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if this.historyStore == nil && !this.historyLoaded {
+        this.historyStore = scheduleFacade.HistoryStore()
+        this.historyLoaded = true
+    }
+
+    return this.historyStore
+}
+
+// 设置错过执行的宽限时间
+func (this *Schedule) WithMissedGrace(grace time.Duration) *Schedule {
+    this.missedGrace = grace
+
+    return this
+}
+
+// 设置是否记录不满足执行条件的跳过，默认不记录
+// 不记录时检测错过执行会忽略设置了执行条件的任务
+func (this *Schedule) WithFilteredHistory(record bool) *Schedule {
+    this.recordFiltered = record
+
+    return this
+}
+
+// 任务最近的 n 条执行记录，按开始时间倒序
+func (this *Schedule) History(name string, n int) ([]*History, error) {
+    store := this.GetHistoryStore()
+    if store == nil {
+        return nil, nil
+    }
+
+    return store.Latest(context.Background(), name, n)
+}
+
+// 任务最后一次执行记录，没有记录时为 nil
+func (this *Schedule) LastRun(name string) *History {
+    histories, err := this.History(name, 1)
+    if err != nil || len(histories) == 0 {
+        return nil
+    }
+
+    return histories[0]
+}
+
+// 检测错过执行的任务
+// 最后一次执行后的下次执行时间加上宽限时间及超时时间仍没有新的记录时为错过执行
+// 没有执行记录的任务不检测，被锁跳过的执行同样有记录
+// 没有记录不满足执行条件的跳过时，设置了执行条件的任务不检测
+func (this *Schedule) CheckMissed(now time.Time) []*Missed {
+    missed := make([]*Missed, 0)
+
+    if this.GetHistoryStore() == nil {
+        return missed
+    }
+
+    grace := this.missedGrace
+    if grace <= 0 {
+        grace = DefaultMissedGrace
+    }
+
+    for _, entry := range this.entries {
+        if entry.Name == "" || entry.Cmd == nil {
+            continue
+        }
+
+        if !this.recordFiltered && entry.hasFilters() {
+            continue
+        }
+
+        last := this.LastRun(entry.Name)
+        if last == nil {
+            continue
+        }
+
+        expected := this.NextRun(entry, last.Start)
+        if expected.IsZero() {
+            continue
+        }
+
+        if now.After(expected.Add(grace + entry.timeout)) {
+            missed = append(missed, &Missed{
+                Entry:    entry,
+                Last:     last,
+                Expected: expected,
+            })
+        }
+    }
+
+    return missed
+}
+
+// 定时检测错过执行的任务并记录警告日志，阻塞到 ctx 结束
+func (this *Schedule) WatchMissed(ctx context.Context, interval time.Duration) {
+    if interval <= 0 {
+        interval = time.Minute
+    }
+
+    ticker := time.NewTicker(interval)
+    defer ticker.Stop()
+
+    // 同一次错过只警告一次
+    warned := make(map[string]time.Time)
+
+    for {
+        select {
+            case <-ctx.Done():
+                return
+
+            case now := <-ticker.C:
+                for _, m := range this.CheckMissed(now) {
+                    if warned[m.Entry.Name].Equal(m.Expected) {
+                        continue
+                    }
+
+                    warned[m.Entry.Name] = m.Expected
+
+                    facade.Logger.
+                        WithField("schedule", m.Entry.Name).
+                        Warnf(
+                            "schedule: [%s] missed run at %s, last run at %s",
+                            m.Entry.Name,
+                            m.Expected.Format("2006-01-02 15:04:05"),
+                            m.Last.Start.Format("2006-01-02 15:04:05"),
+                        )
+                }
+        }
+    }
+}
+
+// 保存不满足执行条件的跳过记录，需要开启记录
+func (this *Schedule) saveFiltered(entry *Entry) {
+    if this.recordFiltered {
+        entry.saveHistory(time.Now(), ErrFiltered)
+    }
+}
+
+// 保存执行记录
+func (this *Entry) saveHistory(start time.Time, err error) {
+    if this.schedule == nil || this.Name == "" {
+        return
+    }
+
+    store := this.schedule.GetHistoryStore()
+    if store == nil {
+        return
+    }
+
+    end := time.Now()
+
+    history := &History{
+        Name:     this.Name,
+        Start:    start,
+        End:      end,
+        Duration: end.Sub(start),
+        Status:   interfaces.StatusSuccess,
+        Host:     hostname(),
+    }
+
+    if err != nil {
+        history.Status = interfaces.StatusFailed
+        history.Error = err.Error()
+
+        if errors.Is(err, ErrSkipped) {
+            history.Status = interfaces.StatusSkipped
+        }
+    }
+
+    if err := store.Save(context.Background(), history); err != nil {
+        facade.Logger.Errorf("schedule: [%s] save history failed: %s", this.Name, err.Error())
+    }
+}
+
+// 服务器名称
+func hostname() string {
+    name, _ := os.Hostname()
+
+    return name
+}
@@ -0,0 +1,180 @@
+package history
+
+import (
+    "time"
+    "context"
+
+    "gorm.io/gorm"
+
+    "github.com/deatil/lakego-doak/lakego/schedule/interfaces"
+)
+
+// 默认表名
+const DefaultTable = "schedule_histories"
+
+/**
+ * 执行记录表
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type ScheduleHistory struct {
+    ID       uint64 `gorm:"column:id;primaryKey;autoIncrement;"`
+    Name     string `gorm:"column:name;size:255;not null;index:idx_name_start;"`
+    Start    int64  `gorm:"column:start;not null;index:idx_name_start;"`
+    End      int64  `gorm:"column:end;not null;"`
+    Duration int64  `gorm:"column:duration;not null;"`
+    Status   string `gorm:"column:status;size:20;not null;"`
+    Error    string `gorm:"column:error;type:text;"`
+    Host     string `gorm:"column:host;size:255;not null;"`
+}
+
+/**
+ * 数据库存储，保存时删除超过保留数量及保留时间的记录
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Database struct {
+    // 数据库
+    db *gorm.DB
+
+    // 表名
+    table string
+
+    // 每个任务保留的记录数量
+    limit int
+
+    // 记录保留时间，0 为不限制
+    maxAge time.Duration
+}
+
+// 构造函数，表不存在时自动创建，创建失败时返回错误
+// limit 小于等于 0 时使用默认数量，maxAge 小于等于 0 时不限制保留时间
+func NewDatabase(db *gorm.DB, table string, limit int, maxAge time.Duration) (*Database, error) {
+    if table == "" {
+        table = DefaultTable
+    }
+
+    if limit <= 0 {
+        limit = DefaultLimit
+    }
+
+    if !db.Migrator().HasTable(table) {
+        if err := db.Table(table).AutoMigrate(&ScheduleHistory{}); err != nil {
+            return nil, err
+        }
+    }
+
+    return &Database{
+        db:     db,
+        table:  table,
+        limit:  limit,
+        maxAge: maxAge,
+    }, nil
+}
+
+// 保存
+func (this *Database) Save(ctx context.Context, history *interfaces.History) error {
+    err := this.query(ctx).Create(&ScheduleHistory{
+        Name:     history.Name,
+        Start:    history.Start.UnixMilli(),
+        End:      history.End.UnixMilli(),
+        Duration: int64(history.Duration),
+        Status:   history.Status,
+        Error:    history.Error,
+        Host:     history.Host,
+    }).Error
+    if err != nil {
+        return err
+    }
+
+    return this.prune(ctx, history.Name)
+}
+
+// 删除超过保留数量及保留时间的记录
+func (this *Database) prune(ctx context.Context, name string) error {
+    if this.maxAge > 0 {
+        err := this.query(ctx).
+            Where("name = ? AND start < ?", name, time.Now().Add(-this.maxAge).UnixMilli()).
+            Delete(&ScheduleHistory{}).Error
+        if err != nil {
+            return err
+        }
+    }
+
+    // 保留数量之外最新的一条，删除该条及更早的记录
+    var list []ScheduleHistory
+
+    err := this.query(ctx).
+        Where("name = ?", name).
+        Order("start desc").
+        Order("id desc").
+        Offset(this.limit).
+        Limit(1).
+        Find(&list).Error
+    if err != nil || len(list) == 0 {
+        return err
+    }
+
+    last := list[0]
+
+    return this.query(ctx).
+        Where("name = ?", name).
+        Where("start < ? OR (start = ? AND id <= ?)", last.Start, last.Start, last.ID).
+        Delete(&ScheduleHistory{}).Error
+}
+
+// 最近的记录
+func (this *Database) Latest(ctx context.Context, name string, n int) ([]*interfaces.History, error) {
+    var list []ScheduleHistory
+
+    query := this.query(ctx).
+        Where("name = ?", name).
+        Order("start desc").
+        Order("id desc")
+    if n > 0 {
+        query = query.Limit(n)
+    }
+
+    if err := query.Find(&list).Error; err != nil {
+        return nil, err
+    }
+
+    histories := make([]*interfaces.History, 0, len(list))
+    for _, history := range list {
+        histories = append(histories, this.format(history))
+    }
+
+    return histories, nil
+}
+
+// 清空
+func (this *Database) Clear(ctx context.Context, name string) error {
+    query := this.query(ctx)
+    if name == "" {
+        query = query.Where("1 = 1")
+    } else {
+        query = query.Where("name = ?", name)
+    }
+
+    return query.Delete(&ScheduleHistory{}).Error
+}
+
+// 转换
+func (this *Database) format(history ScheduleHistory) *interfaces.History {
+    return &interfaces.History{
+        Name:     history.Name,
+        Start:    time.UnixMilli(history.Start),
+        End:      time.UnixMilli(history.End),
+        Duration: time.Duration(history.Duration),
+        Status:   history.Status,
+        Error:    history.Error,
+        Host:     history.Host,
+    }
+}
+
+// 查询
+func (this *Database) query(ctx context.Context) *gorm.DB {
+    return this.db.WithContext(ctx).Table(this.table)
+}
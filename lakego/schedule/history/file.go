@@ -0,0 +1,214 @@
+package history
+
+import (
+    "os"
+    "sync"
+    "bufio"
+    "context"
+    "encoding/json"
+    "path/filepath"
+
+    "github.com/deatil/lakego-doak/lakego/schedule/interfaces"
+)
+
+/**
+ * 文件存储，每行一条 json 记录
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type File struct {
+    // 锁
+    mu sync.Mutex
+
+    // 文件路径
+    path string
+
+    // 每个任务保留的记录数量
+    limit int
+
+    // 上次整理后写入的数量
+    writes int
+}
+
+// 构造函数，limit 小于等于 0 时使用默认数量
+// 写入的记录达到 limit 条时整理文件，每个任务只保留最近 limit 条
+func NewFile(path string, limit int) *File {
+    if limit <= 0 {
+        limit = DefaultLimit
+    }
+
+    return &File{
+        path:   path,
+        limit:  limit,
+        // 第一次写入时整理已有的文件
+        writes: limit,
+    }
+}
+
+// 保存
+func (this *File) Save(ctx context.Context, history *interfaces.History) error {
+    data, err := json.Marshal(history)
+    if err != nil {
+        return err
+    }
+
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if err := os.MkdirAll(filepath.Dir(this.path), 0755); err != nil {
+        return err
+    }
+
+    file, err := os.OpenFile(this.path, os.O_CREATE | os.O_WRONLY | os.O_APPEND, 0644)
+    if err != nil {
+        return err
+    }
+    defer file.Close()
+
+    if _, err = file.Write(append(data, '\n')); err != nil {
+        return err
+    }
+
+    this.writes++
+    if this.writes < this.limit {
+        return nil
+    }
+
+    this.writes = 0
+
+    return this.compact()
+}
+
+// 最近的记录，最多返回保留的数量，文件整理前可能有更多的记录
+func (this *File) Latest(ctx context.Context, name string, n int) ([]*interfaces.History, error) {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if n <= 0 || n > this.limit {
+        n = this.limit
+    }
+
+    list := make([]*interfaces.History, 0)
+
+    err := this.each(func(history *interfaces.History, line []byte) {
+        if history.Name == name {
+            list = append(list, history)
+        }
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    return latest(list, n), nil
+}
+
+// 清空
+func (this *File) Clear(ctx context.Context, name string) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if name == "" {
+        err := os.Remove(this.path)
+        if err != nil && !os.IsNotExist(err) {
+            return err
+        }
+
+        return nil
+    }
+
+    lines := make([][]byte, 0)
+
+    err := this.each(func(history *interfaces.History, line []byte) {
+        if history.Name != name {
+            lines = append(lines, line)
+        }
+    })
+    if err != nil {
+        return err
+    }
+
+    return this.write(lines)
+}
+
+// 整理文件，每个任务只保留最近的记录，需要在锁内调用
+func (this *File) compact() error {
+    names := make([]string, 0)
+    lines := make([][]byte, 0)
+
+    err := this.each(func(history *interfaces.History, line []byte) {
+        names = append(names, history.Name)
+        lines = append(lines, line)
+    })
+    if err != nil {
+        return err
+    }
+
+    // 从最新的记录开始计数
+    counts := make(map[string]int)
+    keep := make([]bool, len(lines))
+    for i := len(lines) - 1; i >= 0; i-- {
+        counts[names[i]]++
+        keep[i] = counts[names[i]] <= this.limit
+    }
+
+    kept := make([][]byte, 0, len(lines))
+    for i, line := range lines {
+        if keep[i] {
+            kept = append(kept, line)
+        }
+    }
+
+    if len(kept) == len(lines) {
+        return nil
+    }
+
+    return this.write(kept)
+}
+
+// 使用临时文件替换记录文件，需要在锁内调用
+func (this *File) write(lines [][]byte) error {
+    tmp := this.path + ".tmp"
+
+    data := make([]byte, 0)
+    for _, line := range lines {
+        data = append(data, line...)
+        data = append(data, '\n')
+    }
+
+    if err := os.WriteFile(tmp, data, 0644); err != nil {
+        return err
+    }
+
+    return os.Rename(tmp, this.path)
+}
+
+// 逐条读取，格式错误的行跳过，需要在锁内调用
+func (this *File) each(fn func(*interfaces.History, []byte)) error {
+    file, err := os.Open(this.path)
+    if err != nil {
+        if os.IsNotExist(err) {
+            return nil
+        }
+
+        return err
+    }
+    defer file.Close()
+
+    scanner := bufio.NewScanner(file)
+    scanner.Buffer(make([]byte, 64 * 1024), 4 * 1024 * 1024)
+
+    for scanner.Scan() {
+        history := &interfaces.History{}
+        if json.Unmarshal(scanner.Bytes(), history) != nil {
+            continue
+        }
+
+        line := make([]byte, len(scanner.Bytes()))
+        copy(line, scanner.Bytes())
+
+        fn(history, line)
+    }
+
+    return scanner.Err()
+}
@@ -0,0 +1,119 @@
+package history
+
+import (
+    "os"
+    "time"
+    "context"
+    "testing"
+    "reflect"
+    "path/filepath"
+
+    "github.com/deatil/lakego-doak/lakego/schedule/interfaces"
+)
+
+func assertT(t *testing.T) func(any, any, string) {
+    return func(actual any, expected any, msg string) {
+        if !reflect.DeepEqual(actual, expected) {
+            t.Errorf("Failed %s: actual: %v, expected: %v", msg, actual, expected)
+        }
+    }
+}
+
+// 保存 n 条记录，开始时间依次增加一秒
+func saveHistories(store interfaces.HistoryStore, name string, n int) {
+    start := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+
+    for i := 0; i < n; i++ {
+        store.Save(context.Background(), &interfaces.History{
+            Name:   name,
+            Start:  start.Add(time.Duration(i) * time.Second),
+            Status: interfaces.StatusSuccess,
+        })
+    }
+}
+
+// 记录的开始秒数
+func seconds(list []*interfaces.History) []int {
+    res := make([]int, 0, len(list))
+    for _, history := range list {
+        res = append(res, history.Start.Second())
+    }
+
+    return res
+}
+
+func Test_Memory(t *testing.T) {
+    assert := assertT(t)
+
+    ctx := context.Background()
+    store := NewMemory(3)
+
+    saveHistories(store, "a", 5)
+    saveHistories(store, "b", 1)
+
+    list, err := store.Latest(ctx, "a", 0)
+    assert(err, nil, "Memory Latest error")
+    assert(seconds(list), []int{4, 3, 2}, "Memory limit")
+
+    list, _ = store.Latest(ctx, "a", 2)
+    assert(seconds(list), []int{4, 3}, "Memory Latest n")
+
+    // 返回的记录不影响保存的记录
+    list[0].Status = interfaces.StatusFailed
+    list, _ = store.Latest(ctx, "a", 1)
+    assert(list[0].Status, interfaces.StatusSuccess, "Memory Latest copy")
+
+    store.Clear(ctx, "a")
+    list, _ = store.Latest(ctx, "a", 0)
+    assert(len(list), 0, "Memory Clear name")
+
+    list, _ = store.Latest(ctx, "b", 0)
+    assert(len(list), 1, "Memory Clear other name")
+
+    store.Clear(ctx, "")
+    list, _ = store.Latest(ctx, "b", 0)
+    assert(len(list), 0, "Memory Clear all")
+}
+
+func Test_File(t *testing.T) {
+    assert := assertT(t)
+
+    ctx := context.Background()
+    path := filepath.Join(t.TempDir(), "schedule", "history.log")
+
+    store := NewFile(path, 3)
+
+    saveHistories(store, "a", 5)
+    saveHistories(store, "b", 1)
+
+    list, err := store.Latest(ctx, "a", 0)
+    assert(err, nil, "File Latest error")
+    assert(seconds(list), []int{4, 3, 2}, "File limit")
+
+    list, _ = store.Latest(ctx, "a", 2)
+    assert(seconds(list), []int{4, 3}, "File Latest n")
+
+    // 格式错误的行跳过
+    file, _ := os.OpenFile(path, os.O_WRONLY | os.O_APPEND, 0644)
+    file.WriteString("not json\n")
+    file.Close()
+
+    list, err = store.Latest(ctx, "b", 0)
+    assert(err, nil, "File Latest invalid line error")
+    assert(len(list), 1, "File Latest invalid line")
+
+    // 重新打开时使用已有的记录
+    list, _ = NewFile(path, 3).Latest(ctx, "a", 0)
+    assert(seconds(list), []int{4, 3, 2}, "File reopen")
+
+    store.Clear(ctx, "a")
+    list, _ = store.Latest(ctx, "a", 0)
+    assert(len(list), 0, "File Clear name")
+
+    list, _ = store.Latest(ctx, "b", 0)
+    assert(len(list), 1, "File Clear other name")
+
+    store.Clear(ctx, "")
+    _, err = os.Stat(path)
+    assert(os.IsNotExist(err), true, "File Clear all")
+}
@@ -0,0 +1,94 @@
+package history
+
+import (
+    "sync"
+    "context"
+
+    "github.com/deatil/lakego-doak/lakego/schedule/interfaces"
+)
+
+// 每个任务默认保留的记录数量
+const DefaultLimit = 100
+
+/**
+ * 内存存储，只在当前进程内有效
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type Memory struct {
+    // 锁
+    mu sync.RWMutex
+
+    // 每个任务保留的记录数量
+    limit int
+
+    // 执行记录
+    histories map[string][]*interfaces.History
+}
+
+// 构造函数，limit 小于等于 0 时使用默认数量
+func NewMemory(limit int) *Memory {
+    if limit <= 0 {
+        limit = DefaultLimit
+    }
+
+    return &Memory{
+        limit:     limit,
+        histories: make(map[string][]*interfaces.History),
+    }
+}
+
+// 保存
+func (this *Memory) Save(ctx context.Context, history *interfaces.History) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    newHistory := *history
+
+    list := append(this.histories[history.Name], &newHistory)
+    if len(list) > this.limit {
+        list = list[len(list) - this.limit:]
+    }
+
+    this.histories[history.Name] = list
+
+    return nil
+}
+
+// 最近的记录
+func (this *Memory) Latest(ctx context.Context, name string, n int) ([]*interfaces.History, error) {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    return latest(this.histories[name], n), nil
+}
+
+// 清空
+func (this *Memory) Clear(ctx context.Context, name string) error {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if name == "" {
+        this.histories = make(map[string][]*interfaces.History)
+    } else {
+        delete(this.histories, name)
+    }
+
+    return nil
+}
+
+// 倒序取出最后 n 条记录
+func latest(list []*interfaces.History, n int) []*interfaces.History {
+    if n <= 0 || n > len(list) {
+        n = len(list)
+    }
+
+    histories := make([]*interfaces.History, 0, n)
+    for i := len(list) - 1; i >= 0 && len(histories) < n; i-- {
+        history := *list[i]
+        histories = append(histories, &history)
+    }
+
+    return histories
+}
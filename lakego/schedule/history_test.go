@@ -0,0 +1,70 @@
+package schedule
+
+import (
+    "time"
+    "testing"
+
+    "github.com/deatil/lakego-doak/lakego/schedule/history"
+)
+
+func Test_FilteredHistory(t *testing.T) {
+    assert := assertT(t)
+
+    store := history.NewMemory(10)
+
+    s := New().
+        WithCron(NewCron(WithSeconds(), WithLocation(time.UTC))).
+        WithHistoryStore(store)
+
+    s.AddFunc(func() {}).WithName("filtered").Cron("0 * * * * *").When(func() bool {
+        return false
+    })
+
+    now := time.Date(2026, 10, 19, 10, 5, 0, 0, time.UTC)
+
+    // 默认不记录不满足执行条件的跳过
+    s.RunDue(now)
+    assert(s.LastRun("filtered"), (*History)(nil), "Filtered not recorded")
+
+    s.WithFilteredHistory(true)
+    s.RunDue(now)
+
+    last := s.LastRun("filtered")
+    assert(last != nil && last.IsSkipped(), true, "Filtered recorded")
+}
+
+func Test_CheckMissedFiltered(t *testing.T) {
+    assert := assertT(t)
+
+    store := history.NewMemory(10)
+
+    s := New().
+        WithCron(NewCron(WithSeconds(), WithLocation(time.UTC))).
+        WithHistoryStore(store)
+
+    s.AddFunc(func() {}).WithName("every-minute").Cron("0 * * * * *")
+    s.AddFunc(func() {}).WithName("filtered").Cron("0 * * * * *").When(func() bool {
+        return false
+    })
+
+    // 执行记录使用当前时间
+    now := time.Now()
+    s.RunDue(now)
+
+    names := func() []string {
+        list := make([]string, 0)
+        for _, m := range s.CheckMissed(now.Add(time.Hour)) {
+            list = append(list, m.Entry.Name)
+        }
+
+        return list
+    }
+
+    assert(names(), []string{"every-minute"}, "CheckMissed without filtered history")
+
+    // 记录不满足执行条件的跳过时检测设置了执行条件的任务
+    s.WithFilteredHistory(true)
+    s.RunDue(now)
+
+    assert(names(), []string{"every-minute", "filtered"}, "CheckMissed with filtered history")
+}
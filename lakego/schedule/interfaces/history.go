@@ -0,0 +1,69 @@
+package interfaces
+
+import (
+    "time"
+    "context"
+)
+
+// 执行状态
+const (
+    StatusSuccess = "success"
+    StatusFailed  = "failed"
+    StatusSkipped = "skipped"
+)
+
+/**
+ * 计划任务执行记录
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type History struct {
+    // 任务名称
+    Name string `json:"name"`
+
+    // 开始时间
+    Start time.Time `json:"start"`
+
+    // 结束时间
+    End time.Time `json:"end"`
+
+    // 执行用时
+    Duration time.Duration `json:"duration"`
+
+    // 执行状态
+    Status string `json:"status"`
+
+    // 错误信息
+    Error string `json:"error,omitempty"`
+
+    // 执行的服务器
+    Host string `json:"host"`
+}
+
+// 是否执行成功
+func (this *History) IsSuccess() bool {
+    return this.Status == StatusSuccess
+}
+
+// 是否跳过执行
+func (this *History) IsSkipped() bool {
+    return this.Status == StatusSkipped
+}
+
+/**
+ * 执行记录存储接口
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type HistoryStore interface {
+    // 保存
+    Save(ctx context.Context, history *History) error
+
+    // 任务最近的 n 条记录，按开始时间倒序，n 小于等于 0 时返回全部
+    Latest(ctx context.Context, name string, n int) ([]*History, error)
+
+    // 清空任务的记录，名称为空时清空全部
+    Clear(ctx context.Context, name string) error
+}
@@ -0,0 +1,95 @@
+package schedule
+
+import (
+    "os"
+    "bytes"
+    "errors"
+    "context"
+    "os/exec"
+
+    "github.com/deatil/lakego-doak/lakego/command"
+)
+
+// 带输出的任务
+type OutputJob interface {
+    // 执行并返回输出
+    Output(ctx context.Context) ([]byte, error)
+}
+
+/**
+ * 在当前进程执行根脚本下的子脚本
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type CommandJob struct {
+    // 所属计划任务
+    schedule *Schedule
+
+    // 脚本及参数
+    Line string
+}
+
+// 执行
+func (this *CommandJob) Run() {
+    this.Output(context.Background())
+}
+
+// 执行并返回输出，只能获取使用 cmd.OutOrStdout() 输出的内容
+func (this *CommandJob) Output(ctx context.Context) ([]byte, error) {
+    root := this.schedule.GetRootCmd()
+    if root == nil {
+        return nil, errors.New("schedule: root command is not set")
+    }
+
+    var out bytes.Buffer
+    err := command.RunSubCommand(ctx, root, this.Line, &out)
+
+    return out.Bytes(), err
+}
+
+/**
+ * 执行外部程序
+ *
+ * @create 2026-10-19
+ * @author deatil
+ */
+type ExecJob struct {
+    // 程序路径
+    Path string
+
+    // 参数
+    Args []string
+}
+
+// 执行
+func (this *ExecJob) Run() {
+    this.Output(context.Background())
+}
+
+// 执行并返回标准输出及错误输出，ctx 结束时结束进程
+// 输出先写入临时文件，子进程未退出时也能在超时后返回
+func (this *ExecJob) Output(ctx context.Context) ([]byte, error) {
+    file, err := os.CreateTemp("", "lakego-schedule-*.log")
+    if err != nil {
+        return nil, err
+    }
+
+    defer func() {
+        file.Close()
+        os.Remove(file.Name())
+    }()
+
+    cmd := exec.CommandContext(ctx, this.Path, this.Args...)
+    cmd.Stdout = file
+    cmd.Stderr = file
+
+    err = cmd.Run()
+
+    output, rerr := os.ReadFile(file.Name())
+    if err == nil {
+        err = rerr
+    }
+
+    return output, err
+}
@@ -0,0 +1,253 @@
+package schedule
+
+import (
+    "os"
+    "fmt"
+    "time"
+    "errors"
+    "context"
+    "strings"
+    "crypto/sha1"
+    "runtime/debug"
+
+    "github.com/deatil/lakego-doak/lakego/facade"
+    "github.com/deatil/lakego-doak/lakego/facade/logger"
+)
+
+// 默认防重叠锁过期时间
+const DefaultOverlapExpire = 24 * time.Hour
+
+// 单服务器锁过期时间
+const oneServerExpire = time.Hour
+
+// 跳过执行时返回的错误
+var ErrSkipped = errors.New("schedule: run skipped")
+
+var (
+    // 获取锁失败
+    ErrLocked = fmt.Errorf("%w, lock is held by another run", ErrSkipped)
+
+    // 不满足执行条件
+    ErrFiltered = fmt.Errorf("%w, filters not passed", ErrSkipped)
+)
+
+// 执行任务，阻塞到任务完成，不检测执行条件
+// 设置防重叠或者单服务器执行时，获取锁失败会跳过本次执行并返回 ErrSkipped
+func (this *Entry) Run() error {
+    ctx := context.Background()
+    if this.schedule != nil {
+        ctx = this.schedule.Context()
+    }
+
+    return this.RunContext(ctx)
+}
+
+// 使用 ctx 执行任务，ctx 取消或者超时后任务的 context 同时取消
+func (this *Entry) RunContext(ctx context.Context) error {
+    return this.RunAt(ctx, time.Now())
+}
+
+// 执行计划时间为 t 的任务，单服务器执行时各服务器使用同一计划时间获取锁
+func (this *Entry) RunAt(ctx context.Context, t time.Time) error {
+    if err := this.validate(); err != nil {
+        return err
+    }
+
+    if this.onOneServer && !this.acquireServerLock(t) {
+        this.saveHistory(time.Now(), ErrLocked)
+
+        return ErrLocked
+    }
+
+    if this.withoutOverlapping {
+        lock := this.getCache().Lock(this.MutexName(), this.overlapExpire)
+
+        ok, err := lock.Get()
+        if err != nil {
+            facade.Logger.Errorf("schedule: [%s] get overlapping lock failed: %s", this.MutexName(), err.Error())
+        }
+
+        if !ok {
+            this.saveHistory(time.Now(), ErrLocked)
+
+            return ErrLocked
+        }
+
+        defer lock.Release()
+    }
+
+    for _, fn := range this.beforeCallbacks {
+        fn()
+    }
+
+    start := time.Now()
+
+    err := this.call(ctx)
+
+    this.saveHistory(start, err)
+
+    for _, fn := range this.afterCallbacks {
+        fn()
+    }
+
+    if err != nil {
+        facade.Logger.
+            WithField("schedule", this.Name).
+            WithError(err).
+            Errorf("schedule: [%s] run failed: %s", this.Name, err.Error())
+
+        for _, fn := range this.failureCallbacks {
+            fn(err)
+        }
+
+        return err
+    }
+
+    for _, fn := range this.successCallbacks {
+        fn()
+    }
+
+    return nil
+}
+
+// 运行脚本，panic 作为错误返回
+// 返回错误的 context 任务在 context 取消后返回取消的错误
+func (this *Entry) call(ctx context.Context) (err error) {
+    defer func() {
+        if r := recover(); r != nil {
+            err = fmt.Errorf("panic: %v\n%s", r, debug.Stack())
+        }
+    }()
+
+    if this.timeout > 0 {
+        var cancel context.CancelFunc
+        ctx, cancel = context.WithTimeout(ctx, this.timeout)
+        defer cancel()
+    }
+
+    switch cmd := this.Cmd.(type) {
+        // 方法
+        case func():
+            cmd()
+
+        // 返回错误的方法
+        case func() error:
+            return cmd()
+
+        // 使用 context 的方法，没有返回值时无法判断是否被中断，返回即为成功
+        case func(context.Context):
+            cmd(ctx)
+
+        // 使用 context 并返回错误的方法
+        case func(context.Context) error:
+            err := cmd(ctx)
+            if err == nil && ctx.Err() != nil {
+                err = ctx.Err()
+            }
+
+            return err
+
+        // 带输出的任务
+        case OutputJob:
+            output, err := cmd.Output(ctx)
+            this.writeOutput(output)
+
+            if err == nil && ctx.Err() != nil {
+                err = ctx.Err()
+            }
+
+            return err
+
+        // job 结构体
+        case IJob:
+            cmd.Run()
+
+        default:
+            return unsupportedCmd(this.Cmd)
+    }
+
+    return nil
+}
+
+// 检测任务设置，设置错误、不支持的脚本或者没有 context 的任务设置超时时间时返回错误
+func (this *Entry) validate() error {
+    if this.err != nil {
+        return this.err
+    }
+
+    switch this.Cmd.(type) {
+        case func(context.Context), func(context.Context) error:
+            return nil
+        case OutputJob:
+            return nil
+        case func(), func() error, IJob:
+            if this.timeout > 0 {
+                return fmt.Errorf("schedule: timeout requires a context command, %T has no context", this.Cmd)
+            }
+
+            return nil
+        default:
+            return unsupportedCmd(this.Cmd)
+    }
+}
+
+// 不支持的脚本类型
+func unsupportedCmd(cmd any) error {
+    return fmt.Errorf("schedule: unsupported command type %T", cmd)
+}
+
+// 保存输出
+func (this *Entry) writeOutput(output []byte) {
+    if len(output) == 0 {
+        return
+    }
+
+    if this.outputChannel != "" {
+        logger.Channel(this.outputChannel).
+            WithField("schedule", this.Name).
+            Info(strings.TrimRight(string(output), "\n"))
+    }
+
+    if this.outputPath == "" {
+        return
+    }
+
+    flag := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+    if this.outputAppend {
+        flag = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+    }
+
+    file, err := os.OpenFile(this.outputPath, flag, 0644)
+    if err != nil {
+        facade.Logger.Errorf("schedule: [%s] open output file failed: %s", this.Name, err.Error())
+        return
+    }
+    defer file.Close()
+
+    file.Write(output)
+}
+
+// 获取单服务器锁，同一计划时间只有一个服务器能获取
+func (this *Entry) acquireServerLock(t time.Time) bool {
+    name := fmt.Sprintf("%s:%d", this.MutexName(), t.Unix())
+
+    ok, err := this.getCache().
+        Lock("server:" + name, oneServerExpire).
+        Get()
+    if err != nil {
+        facade.Logger.Errorf("schedule: [%s] get server lock failed: %s", this.MutexName(), err.Error())
+    }
+
+    return ok
+}
+
+// 锁名称，未设置任务名称时使用计划时间及脚本类型生成
+func (this *Entry) MutexName() string {
+    if this.Name != "" {
+        return "schedule:" + this.Name
+    }
+
+    data := fmt.Sprintf("%s%T", this.Spec, this.Cmd)
+
+    return fmt.Sprintf("schedule:%x", sha1.Sum([]byte(data)))
+}
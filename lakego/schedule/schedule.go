@@ -5,6 +5,12 @@ import (
     "time"
     "sync"
     "context"
+    "strings"
+
+    "github.com/deatil/lakego-doak/lakego/cache"
+    "github.com/deatil/lakego-doak/lakego/facade"
+    "github.com/deatil/lakego-doak/lakego/command"
+    cacheFacade "github.com/deatil/lakego-doak/lakego/facade/cache"
 )
 
 // 常量
@@ -39,6 +45,39 @@ type Schedule struct {
 
     // 已停止的计划任务
     stoped map[string]CronEntry
+
+    // 锁使用的缓存
+    cache *cache.Cache
+
+    // 锁使用的缓存名称，为空时使用默认缓存
+    cacheStore string
+
+    // 后台执行的任务
+    wg sync.WaitGroup
+
+    // 根脚本
+    rootCmd *command.Command
+
+    // 当前环境
+    environment string
+
+    // 执行记录存储
+    historyStore HistoryStore
+
+    // 是否已读取执行记录存储配置
+    historyLoaded bool
+
+    // 错过执行的宽限时间
+    missedGrace time.Duration
+
+    // 是否记录不满足执行条件的跳过
+    recordFiltered bool
+
+    // 任务的 context，停止时取消
+    ctx context.Context
+
+    // 取消任务的 context
+    cancel context.CancelFunc
 }
 
 // 构造函数
@@ -50,11 +89,15 @@ func New() *Schedule {
         WithChain(Recover(logger)),
     )
 
+    ctx, cancel := context.WithCancel(context.Background())
+
     schedule := &Schedule{
         Cron:    cron,
         entries: make([]*Entry, 0),
         cronIDs: make(map[string]CronEntryID),
         stoped:  make(map[string]CronEntry),
+        ctx:     ctx,
+        cancel:  cancel,
     }
 
     return schedule
@@ -67,8 +110,72 @@ func (this *Schedule) WithCron(c *Cron) *Schedule {
     return this
 }
 
+// 设置锁使用的缓存
+func (this *Schedule) WithCache(c *cache.Cache) *Schedule {
+    this.cache = c
+
+    return this
+}
+
+// 设置锁使用的缓存名称
+func (this *Schedule) WithCacheStore(store string) *Schedule {
+    this.cacheStore = store
+
+    return this
+}
+
+// 锁使用的缓存
+func (this *Schedule) GetCache() *cache.Cache {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if this.cache == nil {
+        if this.cacheStore != "" {
+            this.cache = cacheFacade.NewWithType(this.cacheStore, true)
+        } else {
+            this.cache = cacheFacade.Default
+        }
+    }
+
+    return this.cache
+}
+
+// 设置当前环境
+func (this *Schedule) WithEnvironment(env string) *Schedule {
+    this.environment = env
+
+    return this
+}
+
+// 当前环境，未设置时为 server 配置的 mode
+func (this *Schedule) GetEnvironment() string {
+    if this.environment == "" {
+        return defaultEnvironment()
+    }
+
+    return this.environment
+}
+
+// 设置根脚本
+func (this *Schedule) WithRootCmd(cmd *command.Command) *Schedule {
+    this.rootCmd = cmd
+
+    return this
+}
+
+// 获取根脚本
+func (this *Schedule) GetRootCmd() *command.Command {
+    return this.rootCmd
+}
+
+// 默认环境
+func defaultEnvironment() string {
+    return facade.Config("server").GetString("mode")
+}
+
 // 添加数据
 func (this *Schedule) WithEntry(entry *Entry) *Schedule {
+    entry.schedule = this
     this.entries = append(this.entries, entry)
 
     return this
@@ -140,10 +247,11 @@ func (this *Schedule) SetShowLogInfo(logInfo bool) *Schedule {
 }
 
 // AddFunc
-func (this *Schedule) AddFunc(cmd func()) *Entry {
+// 支持 func(), func() error, func(context.Context) 及 func(context.Context) error
+func (this *Schedule) AddFunc(cmd any) *Entry {
     entry := NewEntry().AddFunc(cmd)
 
-    this.entries = append(this.entries, entry)
+    this.WithEntry(entry)
 
     return entry
 }
@@ -152,7 +260,7 @@ func (this *Schedule) AddFunc(cmd func()) *Entry {
 func (this *Schedule) AddJob(cmd IJob) *Entry {
     entry := NewEntry().AddJob(cmd)
 
-    this.entries = append(this.entries, entry)
+    this.WithEntry(entry)
 
     return entry
 }
@@ -161,13 +269,44 @@ func (this *Schedule) AddJob(cmd IJob) *Entry {
 func (this *Schedule) AddSchedule(schedule ISchedule, cmd IJob) *Entry {
     entry := NewEntry().AddSchedule(schedule, cmd)
 
-    this.entries = append(this.entries, entry)
+    this.WithEntry(entry)
+
+    return entry
+}
+
+// 在当前进程执行根脚本下的子脚本
+// s.Command("cache:clear --store=redis").Daily()
+func (this *Schedule) Command(line string) *Entry {
+    entry := NewEntry().
+        WithCmd(&CommandJob{
+            schedule: this,
+            Line:     line,
+        }).
+        WithName(line)
+
+    this.WithEntry(entry)
+
+    return entry
+}
+
+// 执行外部程序
+// s.Exec("/usr/bin/backup.sh", "--full").Timeout(time.Hour).AppendOutputTo("/var/log/backup.log")
+func (this *Schedule) Exec(path string, args ...string) *Entry {
+    entry := NewEntry().
+        WithCmd(&ExecJob{
+            Path: path,
+            Args: args,
+        }).
+        WithName(strings.TrimSpace(path + " " + strings.Join(args, " ")))
+
+    this.WithEntry(entry)
 
     return entry
 }
 
 // 开启
 func (this *Schedule) Start() {
+    this.resetContext()
     this.addEntries()
 
     this.Cron.Start()
@@ -175,6 +314,7 @@ func (this *Schedule) Start() {
 
 // 运行
 func (this *Schedule) Run() {
+    this.resetContext()
     this.addEntries()
 
     this.Cron.Run()
@@ -194,28 +334,49 @@ func (this *Schedule) addEntry(entry *Entry) {
         return
     }
 
+    // 设置错误或者不支持的脚本
+    if err := entry.validate(); err != nil {
+        facade.Logger.
+            WithField("schedule", entry.Name).
+            WithError(err).
+            Errorf("schedule: [%s] add failed: %s", entry.Name, err.Error())
+
+        return
+    }
+
+    entry.schedule = this
+
     var entryID CronEntryID
     var err error
 
-    switch cmd := entry.Cmd.(type) {
-        // 方法
-        case func():
-            entryID, err = this.Cron.AddFunc(entry.Spec, cmd)
-
-        // job 结构体
-        case IJob:
-            if entry.Spec != "" {
-                // 字符
-                entryID, err = this.Cron.AddJob(entry.Spec, cmd)
-            } else if entry.Schedule != nil {
-                // Schedule 结构体
-                entryID = this.Cron.Schedule(entry.Schedule, cmd)
-            }
+    job := IFuncJob(func() {
+        this.mu.RLock()
+        id := entryID
+        this.mu.RUnlock()
+
+        // 使用本次的计划时间，不同服务器的执行时间可能有偏差
+        scheduled := this.Cron.Entry(id).Prev
+        if scheduled.IsZero() {
+            scheduled = time.Now()
+        }
+
+        this.runEntryAt(entry, scheduled)
+    })
+
+    var id CronEntryID
+    if entry.Spec != "" {
+        // 字符
+        id, err = this.Cron.AddJob(entry.Spec, job)
+    } else {
+        // Schedule 结构体
+        id = this.Cron.Schedule(entry.Schedule, job)
     }
 
     if err == nil {
         this.mu.Lock()
 
+        entryID = id
+
         if entry.Name != "" {
             this.cronIDs[entry.Name] = entryID
         } else {
@@ -226,13 +387,88 @@ func (this *Schedule) addEntry(entry *Entry) {
     }
 
     if err != nil {
-        fmt.Println(err.Error())
+        facade.Logger.
+            WithField("schedule", entry.Name).
+            WithError(err).
+            Errorf("schedule: [%s] add failed: %s", entry.Name, err.Error())
     }
 }
 
-// 停止
+// 执行任务，不满足执行条件时跳过，后台执行的任务不等待完成
+func (this *Schedule) RunEntry(entry *Entry) {
+    this.runEntryAt(entry, time.Now())
+}
+
+// 执行计划时间为 t 的任务，不满足执行条件时跳过
+func (this *Schedule) runEntryAt(entry *Entry, t time.Time) {
+    if !entry.FiltersPass() {
+        this.saveFiltered(entry)
+        return
+    }
+
+    this.dispatch(entry, t, nil)
+}
+
+// 执行计划时间为 t 的任务，后台执行的任务不等待完成
+// done 不为空时在任务完成后使用执行结果调用
+func (this *Schedule) dispatch(entry *Entry, t time.Time, done func(error)) {
+    ctx := this.Context()
+
+    run := func() {
+        err := entry.RunAt(ctx, t)
+        if done != nil {
+            done(err)
+        }
+    }
+
+    if !entry.IsBackground() {
+        run()
+        return
+    }
+
+    this.wg.Add(1)
+    go func() {
+        defer this.wg.Done()
+
+        run()
+    }()
+}
+
+// 停止，取消任务的 context，返回的 context 在正在执行及后台执行的任务完成后结束
 func (this *Schedule) Stop() context.Context {
-    return this.Cron.Stop()
+    cronCtx := this.Cron.Stop()
+
+    this.mu.RLock()
+    this.cancel()
+    this.mu.RUnlock()
+
+    ctx, cancel := context.WithCancel(context.Background())
+    go func() {
+        <-cronCtx.Done()
+        this.wg.Wait()
+
+        cancel()
+    }()
+
+    return ctx
+}
+
+// 任务的 context，停止时取消
+func (this *Schedule) Context() context.Context {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    return this.ctx
+}
+
+// 停止后再次开启时重新创建任务的 context
+func (this *Schedule) resetContext() {
+    this.mu.Lock()
+    defer this.mu.Unlock()
+
+    if this.ctx.Err() != nil {
+        this.ctx, this.cancel = context.WithCancel(context.Background())
+    }
 }
 
 // 任务时区
@@ -305,6 +541,15 @@ func (this *Schedule) CronStop(name string) {
     }
 }
 
+// 任务是否已停止
+func (this *Schedule) CronStopped(name string) bool {
+    this.mu.RLock()
+    defer this.mu.RUnlock()
+
+    _, ok := this.stoped[name]
+    return ok
+}
+
 // 计划任务 ID 列表
 func (this *Schedule) CronIDs() map[string]CronEntryID {
     return this.cronIDs
@@ -1,16 +1,36 @@
 package service_provider
 
 import (
+    "fmt"
+    "time"
+
+    "github.com/deatil/lakego-doak/lakego/gmq"
+    "github.com/deatil/lakego-doak/lakego/array"
+    "github.com/deatil/lakego-doak/lakego/router"
     "github.com/deatil/lakego-doak/lakego/schedule"
     "github.com/deatil/lakego-doak/lakego/provider"
+    "github.com/deatil/lakego-doak/lakego/register"
+    "github.com/deatil/lakego-doak/lakego/schedule/history"
+
+    // 中间件
+    "github.com/deatil/lakego-doak/lakego/middleware/cors"
+    "github.com/deatil/lakego-doak/lakego/middleware/secure"
+    "github.com/deatil/lakego-doak/lakego/middleware/throttle"
+    "github.com/deatil/lakego-doak/lakego/middleware/requestid"
 
     // 脚本
+    logCmd "github.com/deatil/lakego-doak/lakego/console/log"
+    queueCmd "github.com/deatil/lakego-doak/lakego/console/queue"
+    routeCmd "github.com/deatil/lakego-doak/lakego/console/route"
     publishCmd "github.com/deatil/lakego-doak/lakego/console/publish"
     storageCmd "github.com/deatil/lakego-doak/lakego/console/storage"
     scheduleCmd "github.com/deatil/lakego-doak/lakego/console/schedule"
+    maintenanceCmd "github.com/deatil/lakego-doak/lakego/console/maintenance"
 
     // 视图
     "github.com/deatil/lakego-doak/lakego/facade"
+    "github.com/deatil/lakego-doak/lakego/facade/logger"
+    "github.com/deatil/lakego-doak/lakego/facade/database"
 )
 
 /**
@@ -28,6 +48,18 @@ func NewLakego() *Lakego {
     return &Lakego{}
 }
 
+// 注册
+func (this *Lakego) Register() {
+    // 中间件别名
+    this.loadMiddleware()
+
+    // 消息处理出错时记录日志
+    this.loadGMQErrorHook()
+
+    // 计划任务执行记录数据库存储
+    this.loadScheduleHistoryStore()
+}
+
 // 引导
 func (this *Lakego) Boot() {
     // 脚本
@@ -35,6 +67,26 @@ func (this *Lakego) Boot() {
 
     // 模板渲染
     this.loadHtmlRender()
+
+    // 路由链接
+    this.loadRouteURL()
+}
+
+/**
+ * 导入中间件别名
+ */
+func (this *Lakego) loadMiddleware() {
+    // 跨域请求
+    router.AliasMiddleware("cors", cors.Handler())
+
+    // 安全响应头
+    router.AliasMiddleware("secure", secure.Handler())
+
+    // 请求 ID
+    router.AliasMiddleware("requestid", requestid.Handler())
+
+    // 请求限流
+    throttle.RegisterAliases()
 }
 
 /**
@@ -46,12 +98,48 @@ func (this *Lakego) loadCommand() {
 
     // 创建软连接
     this.AddCommand(storageCmd.StorageLinkCmd)
+
+    // 查看日志
+    this.AddCommand(logCmd.LogTailCmd)
+
+    // 搜索日志
+    this.AddCommand(logCmd.LogSearchCmd)
+
+    // 执行队列任务
+    this.AddCommand(queueCmd.QueueWorkCmd)
+
+    // 失败的队列任务
+    this.AddCommand(queueCmd.QueueFailedCmd)
+
+    // 重新推送失败的队列任务
+    this.AddCommand(queueCmd.QueueRetryCmd)
+
+    // 清空失败的队列任务
+    this.AddCommand(queueCmd.QueueFlushCmd)
+
+    // 路由列表
+    this.AddCommand(routeCmd.RouteListCmd)
+
+    // 开启维护模式
+    this.AddCommand(maintenanceCmd.DownCmd)
+
+    // 结束维护模式
+    this.AddCommand(maintenanceCmd.UpCmd)
 }
 
 // 计划任务
 func (this *Lakego) Schedule(s *schedule.Schedule) {
     // 计划任务命令
     this.AddCommand(scheduleCmd.NewScheduleCmd(s))
+
+    // 计划任务列表
+    this.AddCommand(scheduleCmd.NewScheduleListCmd(s))
+
+    // 执行当前需要执行的计划任务
+    this.AddCommand(scheduleCmd.NewScheduleRunCmd(s))
+
+    // 立即执行一个计划任务
+    this.AddCommand(scheduleCmd.NewScheduleTestCmd(s))
 }
 
 /**
@@ -60,3 +148,62 @@ func (this *Lakego) Schedule(s *schedule.Schedule) {
 func (this *Lakego) loadHtmlRender() {
     this.GetRoute().HTMLRender = facade.ViewHtml.GetRender()
 }
+
+/**
+ * 导入路由链接使用的域名
+ */
+func (this *Lakego) loadRouteURL() {
+    router.SetBaseURL(facade.Config("server").GetString("app-url"))
+}
+
+/**
+ * 消息处理出错时使用日志记录
+ */
+func (this *Lakego) loadGMQErrorHook() {
+    gmq.SetDefaultErrorHook(func(err *gmq.HandlerError) {
+        fields := map[string]any{
+            "topic":   err.Topic,
+            "pattern": err.Pattern,
+            "payload": fmt.Sprintf("%+v", err.Value),
+        }
+
+        if err.Panic {
+            fields["stack"] = string(err.Stack)
+        }
+
+        logger.Default.
+            WithFields(fields).
+            WithError(err.Err).
+            Error(err.Error())
+    })
+}
+
+/**
+ * 计划任务执行记录数据库存储，使用时才获取数据库连接
+ */
+func (this *Lakego) loadScheduleHistoryStore() {
+    register.
+        NewManagerWithPrefix("schedule-history").
+        Register("database", func(conf map[string]any) any {
+            cfg := array.ArrayFrom(conf)
+
+            db := database.Default
+            if connection := cfg.Value("connection").ToString(); connection != "" {
+                db = database.NewWithType(connection)
+            }
+
+            maxAge, _ := time.ParseDuration(cfg.Value("max-age").ToString())
+
+            store, err := history.NewDatabase(
+                db,
+                cfg.Value("table").ToString(),
+                cfg.Value("limit").ToInt(),
+                maxAge,
+            )
+            if err != nil {
+                panic("计划任务执行记录存储[database]创建失败：" + err.Error())
+            }
+
+            return store
+        })
+}
@@ -0,0 +1,46 @@
+package funcs
+
+import (
+    "github.com/deatil/lakego-doak/lakego/router"
+)
+
+// 注册路由链接函数
+// {{ route("admin.user.edit", {"id": 5}) }}
+// {{ route_url("admin.user.edit", {"id": 5}, {"tab": "info"}) }}
+func init() {
+    AddFuncs(FuncMap{
+        "route":     RouteURL,
+        "route_url": RouteAbsoluteURL,
+    })
+}
+
+// 根据路由别名生成链接
+func RouteURL(name string, params ...map[string]any) (string, error) {
+    return router.URL(name, routeParams(params), routeQuery(params)...)
+}
+
+// 根据路由别名生成带域名的完整链接
+func RouteAbsoluteURL(name string, params ...map[string]any) (string, error) {
+    return router.AbsoluteURL(name, routeParams(params), routeQuery(params)...)
+}
+
+// 路由参数
+func routeParams(params []map[string]any) router.URLParams {
+    if len(params) > 0 {
+        return params[0]
+    }
+
+    return nil
+}
+
+// 查询参数
+func routeQuery(params []map[string]any) []router.URLQuery {
+    query := make([]router.URLQuery, 0)
+    if len(params) > 1 {
+        for _, q := range params[1:] {
+            query = append(query, q)
+        }
+    }
+
+    return query
+}